@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type DiffOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewDiffCmd returns a new cobra.Command for comparing two rulesets
+func NewDiffCmd() *cobra.Command {
+	var opts DiffOptions
+	var unified bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "diff <a> <b>",
+		Short: "Compare two rulesets",
+		Long:  `Compare two rulesets and report their semantic differences. Each side is a local file ('-' for stdin), 'repo:[HOST/]OWNER/REPO#ID-OR-NAME' or 'org:[HOST/]OWNER#ID-OR-NAME'. Both sides are normalized to the export format, and IDs and sources are ignored. Use --unified to show a unified diff instead of the list of changes.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			sources := make([]*rulekit.Source, 0, len(args))
+			for _, arg := range args {
+				source, err := rulekit.ParseSource(arg)
+				if err != nil {
+					return fmt.Errorf("error parsing ruleset source: %w", err)
+				}
+				sources = append(sources, source)
+			}
+
+			left, err := sources[0].Load(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load ruleset from %s: %w", sources[0], err)
+			}
+			right, err := sources[1].Load(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load ruleset from %s: %w", sources[1], err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			if unified {
+				diff, err := rulekit.UnifiedDiff(left, right, sources[0].String(), sources[1].String())
+				if err != nil {
+					return fmt.Errorf("failed to compute unified diff: %w", err)
+				}
+				renderer.RenderUnifiedDiff(diff)
+				return nil
+			}
+
+			changes, err := rulekit.DiffConfig(left, right)
+			if err != nil {
+				return fmt.Errorf("failed to compare rulesets: %w", err)
+			}
+			renderer.RenderChanges(changes)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVarP(&unified, "unified", "u", false, "Show a unified diff instead of the list of changes")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in diff output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewDiffCmd())
+}
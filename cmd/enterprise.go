@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/cmd/enterprise"
+)
+
+// NewEnterpriseCmd returns a new cobra.Command for enterprise commands
+func NewEnterpriseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "enterprise",
+		Short: "Manage enterprise rulesets",
+		Long:  `Commands to manage enterprise rulesets`,
+	}
+
+	cmd.AddCommand(enterprise.NewDeleteCmd())
+	cmd.AddCommand(enterprise.NewExportCmd())
+	cmd.AddCommand(enterprise.NewGetCmd())
+	cmd.AddCommand(enterprise.NewImportCmd())
+	cmd.AddCommand(enterprise.NewListCmd())
+	cmd.AddCommand(enterprise.NewMigrateCmd())
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewEnterpriseCmd())
+}
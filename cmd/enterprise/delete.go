@@ -0,0 +1,56 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+// NewDeleteCmd returns a new cobra.Command for deleting an enterprise ruleset
+func NewDeleteCmd() *cobra.Command {
+	var enterprise string
+
+	cmd := &cobra.Command{
+		Use:   "delete <ruleset-id>",
+		Short: "Delete an enterprise ruleset",
+		Long:  `Delete a specific enterprise ruleset by its ID. The ruleset is backed up to a snapshot first, which can be put back with the restore command.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid ruleset ID: %w", err)
+			}
+
+			repository, err := parser.Repository(parser.RepositoryOwnerWithHost(enterprise))
+			if err != nil {
+				return fmt.Errorf("error parsing enterprise: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			err = rulekit.RemoveEnterpriseRuleset(ctx, client, repository.Owner, rulesetID)
+			if err != nil {
+				return fmt.Errorf("failed to delete enterprise ruleset: %w", err)
+			}
+
+			logger.Info("Deletion completed successfully.", "rulesetID", rulesetID, "enterprise", repository.Owner)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&enterprise, "enterprise", "", "The enterprise slug in the format '[HOST/]ENTERPRISE'")
+	_ = cmd.MarkFlagRequired("enterprise")
+
+	return cmd
+}
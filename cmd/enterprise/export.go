@@ -0,0 +1,98 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+// NewExportCmd returns a new cobra.Command for exporting an enterprise ruleset
+func NewExportCmd() *cobra.Command {
+	var enterprise string
+	var output string
+	var format string
+	var all bool
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "export [<ruleset-id>]",
+		Short: "Export enterprise rulesets to JSON or YAML files",
+		Long:  `Export a specific enterprise ruleset by its ID to a JSON or YAML file. The format is chosen by --format or by the extension of the output file, and defaults to JSON. When overwriting an existing YAML file, its comments are kept for the fields that still exist. Use --all with --dir to export every ruleset to its own file named after the slug of the ruleset name. The exported file can be used for backup or to import into another enterprise.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwnerWithHost(enterprise))
+			if err != nil {
+				return fmt.Errorf("error parsing enterprise: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			if all {
+				paths, err := rulekit.ExportEnterpriseRulesets(ctx, client, repository.Owner, dir, rulekit.ResolveFormat(format, ""))
+				if err != nil {
+					return fmt.Errorf("failed to export enterprise rulesets: %w", err)
+				}
+				logger.Info("Export completed successfully.", "dir", dir, "count", len(paths), "enterprise", repository.Owner)
+				return nil
+			}
+
+			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid ruleset ID: %w", err)
+			}
+
+			ruleset, err := rulekit.GetEnterpriseRuleset(ctx, client, repository.Owner, rulesetID)
+			if err != nil {
+				return fmt.Errorf("failed to get enterprise ruleset: %w", err)
+			}
+
+			config := gh.ExportRuleset(ruleset)
+
+			if output == "" || output == "-" {
+				// Output to stdout
+				data, err := rulekit.MarshalConfig(config, rulekit.ResolveFormat(format, ""))
+				if err != nil {
+					return fmt.Errorf("failed to marshal ruleset: %w", err)
+				}
+				fmt.Print(string(data))
+			} else {
+				// Output to file
+				err = rulekit.WriteConfigFile(output, config, rulekit.ResolveFormat(format, output))
+				if err != nil {
+					return fmt.Errorf("failed to write ruleset to file: %w", err)
+				}
+				logger.Info("Export completed successfully.", "output", output)
+			}
+
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&enterprise, "enterprise", "", "The enterprise slug in the format '[HOST/]ENTERPRISE'")
+	_ = cmd.MarkFlagRequired("enterprise")
+	f.StringVarP(&output, "output", "o", "", "Output file path (default: stdout)")
+	cmdutil.StringEnumFlag(cmd, &format, "format", "", "", rulekit.Formats, "Output file format (default: by output file extension, otherwise json)")
+	f.BoolVar(&all, "all", false, "Export every ruleset to the directory specified by --dir")
+	f.StringVarP(&dir, "dir", "d", "", "Output directory for --all")
+	cmd.MarkFlagsRequiredTogether("all", "dir")
+	cmd.MarkFlagsMutuallyExclusive("dir", "output")
+
+	return cmd
+}
@@ -0,0 +1,64 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type GetOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewGetCmd returns a new cobra.Command for getting an enterprise ruleset
+func NewGetCmd() *cobra.Command {
+	var opts GetOptions
+	var enterprise string
+
+	cmd := &cobra.Command{
+		Use:   "get <ruleset-id>",
+		Short: "Get an enterprise ruleset",
+		Long:  `Get detailed information about a specific enterprise ruleset by its ID.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid ruleset ID: %w", err)
+			}
+
+			repository, err := parser.Repository(parser.RepositoryOwnerWithHost(enterprise))
+			if err != nil {
+				return fmt.Errorf("error parsing enterprise: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			ruleset, err := rulekit.GetEnterpriseRuleset(ctx, client, repository.Owner, rulesetID)
+			if err != nil {
+				return fmt.Errorf("failed to get enterprise ruleset: %w", err)
+			}
+
+			renderer := render.NewRenderer(opts.Exporter)
+			renderer.RenderRepositoryRuleset(ruleset, true)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&enterprise, "enterprise", "", "The enterprise slug in the format '[HOST/]ENTERPRISE'")
+	_ = cmd.MarkFlagRequired("enterprise")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
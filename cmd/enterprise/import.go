@@ -0,0 +1,135 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type ImportOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewImportCmd returns a new cobra.Command for importing an enterprise ruleset
+func NewImportCmd() *cobra.Command {
+	var opts ImportOptions
+	var enterprise string
+	var input string
+	var createIfNotExists bool
+	var plan bool
+	var colorFlag string
+	var templateVars []string
+	var varsFile string
+
+	cmd := &cobra.Command{
+		Use:   "import <input>",
+		Short: "Import enterprise rulesets from JSON or YAML files",
+		Long:  `Import enterprise rulesets from a JSON or YAML file ('-' for stdin), a directory or a glob pattern. A file may hold a single ruleset, a JSON array of rulesets or a multi-document YAML. The format is chosen by the file extension and detected from the content otherwise. Each ruleset is matched by ID and then by name, and the result of each one is reported; files that cannot be read or parsed are reported as failures without stopping the others. The command fails if any of them fails. Use --create-if-none flag to create a new ruleset if it does not exist. Use --plan flag to show a field-level diff of the changes without writing them. Ruleset files may be Go templates filled with --var and --vars-file, see Ruleset Templates in the README.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input = args[0]
+
+			repository, err := parser.Repository(parser.RepositoryOwnerWithHost(enterprise))
+			if err != nil {
+				return fmt.Errorf("error parsing enterprise: %w", err)
+			}
+
+			vars, err := rulekit.LoadTemplateVars(varsFile, templateVars)
+			if err != nil {
+				return fmt.Errorf("failed to read template variables: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			td := rulekit.NewTemplateData(ctx, client, repository, vars)
+			files, err := rulekit.LoadConfigInput(input, td)
+			if err != nil {
+				return fmt.Errorf("failed to read ruleset files: %w", err)
+			}
+
+			if plan {
+				var plans []*rulekit.Plan
+				for _, file := range files {
+					if file.Err != nil {
+						logger.Error("Failed to read ruleset file", "path", file.Path, "error", file.Err)
+						continue
+					}
+					p, err := rulekit.PlanEnterpriseImport(ctx, client, repository.Owner, file.Config, createIfNotExists)
+					if err != nil {
+						if len(files) == 1 {
+							return fmt.Errorf("failed to plan enterprise ruleset: %w", err)
+						}
+						logger.Error("Failed to plan ruleset", "name", file.Config.Name, "path", file.String(), "error", err)
+						continue
+					}
+					plans = append(plans, p)
+				}
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans(plans)
+				if len(plans) < len(files) {
+					return fmt.Errorf("failed to plan %d of %d rulesets", len(files)-len(plans), len(files))
+				}
+				return nil
+			}
+
+			if len(files) == 1 {
+				resultRuleset, action, err := rulekit.ImportEnterpriseConfig(ctx, client, repository.Owner, files[0].Config, createIfNotExists)
+				if err != nil {
+					return fmt.Errorf("failed to import enterprise ruleset: %w", err)
+				}
+				logger.Info("Successfully imported ruleset.", "action", action, "rulesetID", *resultRuleset.ID, "rulesetName", resultRuleset.Name, "enterprise", repository.Owner)
+
+				renderer := render.NewRenderer(opts.Exporter)
+				renderer.RenderRepositoryRuleset(resultRuleset, true)
+				return nil
+			}
+
+			logger.Info("Starting import", "enterprise", repository.Owner, "count", len(files))
+			successCount := 0
+			for _, file := range files {
+				if file.Err != nil {
+					logger.Error("Failed to read ruleset file", "path", file.Path, "error", file.Err)
+					continue
+				}
+				resultRuleset, action, err := rulekit.ImportEnterpriseConfig(ctx, client, repository.Owner, file.Config, createIfNotExists)
+				if err != nil {
+					logger.Error("Failed to import ruleset", "name", file.Config.Name, "path", file.String(), "error", err)
+					continue
+				}
+				logger.Info("Successfully imported ruleset.", "action", action, "rulesetID", *resultRuleset.ID, "rulesetName", resultRuleset.Name, "path", file.String())
+				successCount++
+			}
+
+			logger.Info("Import completed", "total", len(files), "success", successCount, "failed", len(files)-successCount)
+			if successCount < len(files) {
+				return fmt.Errorf("failed to import %d of %d rulesets", len(files)-successCount, len(files))
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&enterprise, "enterprise", "", "The enterprise slug in the format '[HOST/]ENTERPRISE'")
+	_ = cmd.MarkFlagRequired("enterprise")
+	f.BoolVarP(&createIfNotExists, "create-if-none", "c", false, "Create a new ruleset if it does not exist")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	f.StringArrayVar(&templateVars, "var", nil, "Set a template variable in the format 'key=value' (can be specified multiple times)")
+	f.StringVar(&varsFile, "vars-file", "", "Read template variables from a JSON or YAML file")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
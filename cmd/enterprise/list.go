@@ -0,0 +1,59 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type ListOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewListCmd returns a new cobra.Command for listing enterprise rulesets
+func NewListCmd() *cobra.Command {
+	var opts ListOptions
+	var enterprise string
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List enterprise rulesets",
+		Long:    `List all rulesets for an enterprise.`,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwnerWithHost(enterprise))
+			if err != nil {
+				return fmt.Errorf("error parsing enterprise: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			rulesets, err := rulekit.ListEnterpriseRulesets(ctx, client, repository.Owner)
+			if err != nil {
+				return fmt.Errorf("failed to list enterprise rulesets: %w", err)
+			}
+
+			renderer := render.NewRenderer(opts.Exporter)
+			renderer.RenderRepositoryRulesetsDefault(rulesets)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&enterprise, "enterprise", "", "The enterprise slug in the format '[HOST/]ENTERPRISE'")
+	_ = cmd.MarkFlagRequired("enterprise")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
@@ -0,0 +1,168 @@
+package enterprise
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type MigrateOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewMigrateCmd returns a new cobra.Command for migrating enterprise rulesets
+func NewMigrateCmd() *cobra.Command {
+	var opts MigrateOptions
+	var plan bool
+	var colorFlag string
+	var actorMapFile string
+
+	cmd := &cobra.Command{
+		Use:   "migrate <[HOST/]src-enterprise> <[HOST/]dst-enterprise> [ruleset-id...]",
+		Short: "Migrate enterprise rulesets to another enterprise",
+		Long:  `Migrate enterprise rulesets from source enterprise to destination enterprise. If ruleset IDs are not specified, all rulesets will be migrated. Rulesets are matched with the destination by name and updated, or created when they do not exist. Bypass actors are resolved for the destination: organization admins, deploy keys, built-in repository roles and apps of the same host keep their IDs, and the other actors must be mapped with --actor-map, teams and custom repository roles by ID; a ruleset with an actor that cannot be resolved fails instead of being migrated with IDs of the source. The file is JSON or YAML with an 'actors' list of entries with 'type' (Team, Integration, OrganizationAdmin, RepositoryRole, or DeployKey), 'source' and 'destination', each an ID or a name (app slug or built-in repository role name); a destination of 'none' drops the actor. Organizations of organization ID conditions are matched by login. Use --plan flag to show a field-level diff against the destination rulesets without writing them, computed after the actors and organizations are mapped. Source enterprise is specified as the first argument, destination enterprise is specified as the second argument.`,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			srcEnterprise := args[0]
+			dstEnterprise := args[1]
+
+			// Parse source enterprise
+			srcRepository, err := parser.Repository(parser.RepositoryOwnerWithHost(srcEnterprise))
+			if err != nil {
+				return fmt.Errorf("error parsing source enterprise: %w", err)
+			}
+
+			// Parse destination enterprise
+			dstRepository, err := parser.Repository(parser.RepositoryOwnerWithHost(dstEnterprise))
+			if err != nil {
+				return fmt.Errorf("error parsing destination enterprise: %w", err)
+			}
+
+			ctx := context.Background()
+
+			// Create clients for source and destination
+			srcClient, err := gh.NewGitHubClientWithRepo(srcRepository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client for source enterprise: %w", err)
+			}
+
+			dstClient, err := gh.NewGitHubClientWithRepo(dstRepository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client for destination enterprise: %w", err)
+			}
+
+			var actorMap *rulekit.ActorMap
+			if actorMapFile != "" {
+				actorMap, err = rulekit.LoadActorMap(actorMapFile)
+				if err != nil {
+					return fmt.Errorf("failed to load actor map: %w", err)
+				}
+			}
+			resolver := rulekit.NewEnterpriseActorResolver(actorMap, srcClient, srcRepository, dstClient, dstRepository)
+
+			var rulesetIDs []int64
+			if len(args) > 2 {
+				// Parse specified ruleset IDs
+				for _, idStr := range args[2:] {
+					id, err := strconv.ParseInt(idStr, 10, 64)
+					if err != nil {
+						return fmt.Errorf("invalid ruleset ID '%s': %w", idStr, err)
+					}
+					rulesetIDs = append(rulesetIDs, id)
+				}
+			} else {
+				// Get all rulesets from source enterprise
+				rulesets, err := rulekit.ListEnterpriseRulesets(ctx, srcClient, srcRepository.Owner)
+				if err != nil {
+					return fmt.Errorf("failed to list enterprise rulesets: %w", err)
+				}
+				for _, ruleset := range rulesets {
+					if ruleset.ID != nil {
+						rulesetIDs = append(rulesetIDs, *ruleset.ID)
+					}
+				}
+			}
+
+			if len(rulesetIDs) == 0 {
+				logger.Info("No rulesets to migrate")
+				return nil
+			}
+
+			logger.Info("Starting migration", "source", srcRepository.Owner, "destination", dstRepository.Owner, "count", len(rulesetIDs))
+
+			// Migrate each ruleset
+			successCount := 0
+			var plans []*rulekit.Plan
+			for _, rulesetID := range rulesetIDs {
+				logger.Info("Migrating ruleset", "id", rulesetID)
+
+				ruleset, err := rulekit.GetEnterpriseRuleset(ctx, srcClient, srcRepository.Owner, rulesetID)
+				if err != nil {
+					logger.Error("Failed to export ruleset", "id", rulesetID, "error", err)
+					continue
+				}
+				if err := resolver.MapEnterpriseRuleset(ctx, ruleset); err != nil {
+					logger.Error("Failed to map ruleset actors", "id", rulesetID, "name", ruleset.Name, "error", err)
+					continue
+				}
+				// Match the destination by name only, since IDs are not shared between enterprises
+				config := rulekit.NormalizeConfig(gh.ExportRuleset(ruleset))
+
+				if plan {
+					p, err := rulekit.PlanEnterpriseImport(ctx, dstClient, dstRepository.Owner, config, true)
+					if err != nil {
+						logger.Error("Failed to compute ruleset plan", "name", config.Name, "error", err)
+						continue
+					}
+					plans = append(plans, p)
+					successCount++
+					continue
+				}
+
+				migrated, action, err := rulekit.ImportEnterpriseConfig(ctx, dstClient, dstRepository.Owner, config, true)
+				if err != nil {
+					logger.Error("Failed to import ruleset", "name", config.Name, "error", err)
+					continue
+				}
+
+				logger.Info("Successfully migrated ruleset", "src_id", rulesetID, "dst_id", *migrated.ID, "name", migrated.Name, "action", action)
+				successCount++
+			}
+
+			if plan {
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans(plans)
+				if successCount < len(rulesetIDs) {
+					return fmt.Errorf("failed to plan %d rulesets", len(rulesetIDs)-successCount)
+				}
+				return nil
+			}
+
+			logger.Info("Migration completed", "total", len(rulesetIDs), "success", successCount, "failed", len(rulesetIDs)-successCount)
+
+			if successCount == 0 {
+				return fmt.Errorf("failed to migrate any rulesets")
+			}
+
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&actorMapFile, "actor-map", "", "Map bypass actors and status check integrations with a JSON or YAML file, failing on actors that cannot be resolved")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+// Run executes the root command and exits with the code carried by an ExitCodeError, or 1 on any other error.
+// Exiting here rather than inside a command lets the command return normally and its output be flushed first.
+func Run() {
+	err := rootCmd.Execute()
+	var exitErr *rulekit.ExitCodeError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.Code)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+}
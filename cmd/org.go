@@ -13,13 +13,22 @@ func NewOrgCmd() *cobra.Command {
 		Long:  `Commands to manage organization rulesets`,
 	}
 
+	cmd.AddCommand(org.NewApplyCmd())
+	cmd.AddCommand(org.NewBypassCmd())
+	cmd.AddCommand(org.NewConditionCmd())
 	cmd.AddCommand(org.NewDeleteCmd())
+	cmd.AddCommand(org.NewDriftCmd())
+	cmd.AddCommand(org.NewEnforceCmd())
 	cmd.AddCommand(org.NewExportCmd())
 	cmd.AddCommand(org.NewGetCmd())
+	cmd.AddCommand(org.NewHistoryCmd())
 	cmd.AddCommand(org.NewImportCmd())
 	cmd.AddCommand(org.NewInsightCmd())
 	cmd.AddCommand(org.NewListCmd())
 	cmd.AddCommand(org.NewMigrateCmd())
+	cmd.AddCommand(org.NewPromoteCmd())
+	cmd.AddCommand(org.NewRevertCmd())
+	cmd.AddCommand(org.NewRuleCmd())
 
 	return cmd
 }
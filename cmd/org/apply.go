@@ -0,0 +1,120 @@
+package org
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type ApplyOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewApplyCmd returns a new cobra.Command for applying a directory of organization ruleset files
+func NewApplyCmd() *cobra.Command {
+	var opts ApplyOptions
+	var owner string
+	var prune bool
+	var plan bool
+	var colorFlag string
+	var templateVars []string
+	var varsFile string
+
+	cmd := &cobra.Command{
+		Use:   "apply <dir>",
+		Short: "Apply a directory of ruleset files to an organization",
+		Long:  `Apply every ruleset file (.json, .yaml or .yml) in a directory to an organization. Each file is matched to a live ruleset by ID and then by name, and the ruleset is created or updated as needed. Use --prune to delete live rulesets that have no matching file. Use --plan to show a field-level diff of the changes without writing them. If org is not specified, the current repository's organization will be used. Ruleset files may be Go templates filled with --var and --vars-file, see Ruleset Templates in the README.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := args[0]
+
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			vars, err := rulekit.LoadTemplateVars(varsFile, templateVars)
+			if err != nil {
+				return fmt.Errorf("failed to read template variables: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			td := rulekit.NewTemplateData(ctx, client, repository, vars)
+			files, err := rulekit.LoadConfigDir(dir, td)
+			if err != nil {
+				return fmt.Errorf("failed to read ruleset files: %w", err)
+			}
+
+			changes, err := rulekit.PlanApply(ctx, client, repository, files, prune)
+			if err != nil {
+				return fmt.Errorf("failed to plan organization rulesets: %w", err)
+			}
+
+			if plan {
+				plans := make([]*rulekit.Plan, 0, len(changes))
+				for _, change := range changes {
+					p, err := rulekit.NewApplyChangePlan(repository.Owner, change)
+					if err != nil {
+						return fmt.Errorf("failed to compute ruleset plan: %w", err)
+					}
+					plans = append(plans, p)
+				}
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans(plans)
+				return nil
+			}
+
+			logger.Info("Starting apply", "organization", repository.Owner, "count", len(changes))
+
+			counts := map[rulekit.ApplyAction]int{}
+			failedCount := 0
+			for _, change := range changes {
+				result, err := rulekit.ExecuteApplyChange(ctx, client, repository, change)
+				if err != nil {
+					logger.Error("Failed to apply ruleset", "action", change.Action, "name", change.Name, "path", change.Path, "error", err)
+					failedCount++
+					continue
+				}
+				logger.Info("Applied ruleset", "action", change.Action, "name", change.Name, "rulesetID", result.GetID())
+				counts[change.Action]++
+			}
+
+			logger.Info("Apply completed",
+				"created", counts[rulekit.ApplyActionCreate],
+				"updated", counts[rulekit.ApplyActionUpdate],
+				"deleted", counts[rulekit.ApplyActionDelete],
+				"unchanged", counts[rulekit.ApplyActionNoChange],
+				"failed", failedCount)
+
+			if failedCount > 0 {
+				return fmt.Errorf("failed to apply %d rulesets", failedCount)
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.BoolVar(&prune, "prune", false, "Delete rulesets that have no matching file")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	f.StringArrayVar(&templateVars, "var", nil, "Set a template variable in the format 'key=value' (can be specified multiple times)")
+	f.StringVar(&varsFile, "vars-file", "", "Read template variables from a JSON or YAML file")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
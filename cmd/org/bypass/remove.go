@@ -0,0 +1,89 @@
+package bypass
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type RemoveOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewRemoveCmd returns a new cobra.Command for removing a bypass actor from an organization ruleset
+func NewRemoveCmd() *cobra.Command {
+	var opts RemoveOptions
+	var owner string
+	var actor rulekit.BypassActorSpec
+	var plan bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "remove <ruleset>",
+		Short: "Remove a bypass actor from an organization ruleset",
+		Long:  `Remove a bypass actor from an organization ruleset, specified by ID or name. The actor is given by exactly one of --team, --app and --role, each taking an ID or a name (a team slug, an app slug, or a repository role name such as 'maintain'), or by --org-admin or --deploy-key. The ruleset is backed up before it is updated. Use --plan flag to show a field-level diff of the change without writing it. If org is not specified, the current repository's organization will be used.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			actorType, actorID, err := actor.Resolve(ctx, client, repository)
+			if err != nil {
+				return fmt.Errorf("failed to resolve bypass actor: %w", err)
+			}
+			p, err := rulekit.PlanRulesetEdit(ctx, client, repository, repository.Owner, args[0], rulekit.RemoveBypassActor(actorType, actorID))
+			if err != nil {
+				return fmt.Errorf("failed to remove bypass actor: %w", err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderPlans([]*rulekit.Plan{p})
+			if plan || p.Action == rulekit.ApplyActionNoChange {
+				return nil
+			}
+			if guardrails.IsReadonly() {
+				return fmt.Errorf("cannot edit ruleset in read-only mode")
+			}
+
+			updated, err := rulekit.ApplyRulesetEdit(ctx, client, repository, p)
+			if err != nil {
+				return fmt.Errorf("failed to update organization ruleset: %w", err)
+			}
+			logger.Info("Successfully removed bypass actor.", "rulesetID", updated.GetID(), "rulesetName", updated.Name, "actorType", actorType)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.StringVar(&actor.Team, "team", "", "Team ID or slug")
+	f.StringVar(&actor.App, "app", "", "GitHub App ID or slug")
+	f.StringVar(&actor.Role, "role", "", "Repository role ID or name (e.g., 'maintain', 'write', 'admin')")
+	f.BoolVar(&actor.OrganizationAdmin, "org-admin", false, "Organization admins")
+	f.BoolVar(&actor.DeployKey, "deploy-key", false, "Deploy keys")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+	cmd.MarkFlagsMutuallyExclusive("team", "app", "role", "org-admin", "deploy-key")
+	cmd.MarkFlagsOneRequired("team", "app", "role", "org-admin", "deploy-key")
+
+	return cmd
+}
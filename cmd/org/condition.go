@@ -0,0 +1,20 @@
+package org
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/cmd/org/condition"
+)
+
+// NewConditionCmd returns a new cobra.Command for editing the conditions of an organization ruleset
+func NewConditionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "condition",
+		Short: "Edit the conditions of an organization ruleset",
+		Long:  `Commands to edit the ref and repository name patterns an organization ruleset includes and excludes in place`,
+	}
+
+	cmd.AddCommand(condition.NewExcludeCmd())
+	cmd.AddCommand(condition.NewIncludeCmd())
+
+	return cmd
+}
@@ -2,12 +2,13 @@ package org
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
+	"path/filepath"
 	"strconv"
 
+	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/logger"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
@@ -17,18 +18,25 @@ import (
 func NewExportCmd() *cobra.Command {
 	var owner string
 	var output string
+	var format string
+	var all bool
+	var dir string
+	var includeRepos bool
 
 	cmd := &cobra.Command{
-		Use:   "export <ruleset-id>",
-		Short: "Export an organization ruleset to JSON file",
-		Long:  `Export a specific organization ruleset by its ID to a JSON file. If org is not specified, the current repository's organization will be used. The exported JSON can be used for backup or to import into another organization.`,
-		Args:  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid ruleset ID: %w", err)
+		Use:   "export [<ruleset-id>]",
+		Short: "Export organization rulesets to JSON or YAML files",
+		Long:  `Export a specific organization ruleset by its ID to a JSON or YAML file. The format is chosen by --format or by the extension of the output file, and defaults to JSON. When overwriting an existing YAML file, its comments are kept for the fields that still exist. Use --all with --dir to export every ruleset to its own file named after the slug of the ruleset name. Add --include-repos to also walk every repository in the organization that is not archived and write its repository-level rulesets to <dir>/<repo>/. If org is not specified, the current repository's organization will be used. The exported file can be used for backup or to import into another organization.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if includeRepos && !all {
+				return fmt.Errorf("--include-repos requires --all")
 			}
-
+			if all {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
 			repository, err := parser.Repository(parser.RepositoryOwner(owner))
 			if err != nil {
 				return fmt.Errorf("error parsing repository: %w", err)
@@ -40,6 +48,48 @@ func NewExportCmd() *cobra.Command {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
 
+			if all {
+				fileFormat := rulekit.ResolveFormat(format, "")
+				paths, err := rulekit.ExportRulesets(ctx, client, repository, dir, fileFormat)
+				if err != nil {
+					return fmt.Errorf("failed to export organization rulesets: %w", err)
+				}
+				logger.Info("Exported organization rulesets.", "dir", dir, "count", len(paths), "organization", repository.Owner)
+				if !includeRepos {
+					return nil
+				}
+
+				repos, err := rulekit.QueryRepositories(ctx, client, repository, &rulekit.RepositoryQuery{})
+				if err != nil {
+					return fmt.Errorf("failed to list organization repositories: %w", err)
+				}
+				failedCount := 0
+				total := len(paths)
+				for _, repo := range repos {
+					repoPaths, err := rulekit.ExportRulesets(ctx, client, repo, filepath.Join(dir, repo.Name), fileFormat)
+					total += len(repoPaths)
+					if err != nil {
+						logger.Error("Failed to export repository rulesets", "repository", parser.GetRepositoryFullName(repo), "error", err)
+						failedCount++
+						continue
+					}
+					if len(repoPaths) > 0 {
+						logger.Info("Exported repository rulesets.", "repository", parser.GetRepositoryFullName(repo), "count", len(repoPaths))
+					}
+				}
+
+				logger.Info("Export completed", "dir", dir, "rulesets", total, "repositories", len(repos), "failed", failedCount)
+				if failedCount > 0 {
+					return fmt.Errorf("failed to export rulesets of %d repositories", failedCount)
+				}
+				return nil
+			}
+
+			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid ruleset ID: %w", err)
+			}
+
 			ruleset, err := gh.GetOrgRuleset(ctx, client, repository, rulesetID)
 			if err != nil {
 				return fmt.Errorf("failed to get organization ruleset: %w", err)
@@ -47,20 +97,18 @@ func NewExportCmd() *cobra.Command {
 
 			config := gh.ExportRuleset(ruleset)
 
-			var jsonData []byte
-			jsonData, err = json.MarshalIndent(config, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal ruleset to JSON: %w", err)
-			}
-
 			if output == "" || output == "-" {
 				// Output to stdout
-				fmt.Println(string(jsonData))
+				data, err := rulekit.MarshalConfig(config, rulekit.ResolveFormat(format, ""))
+				if err != nil {
+					return fmt.Errorf("failed to marshal ruleset: %w", err)
+				}
+				fmt.Print(string(data))
 			} else {
 				// Output to file
-				err = os.WriteFile(output, jsonData, 0644)
+				err = rulekit.WriteConfigFile(output, config, rulekit.ResolveFormat(format, output))
 				if err != nil {
-					return fmt.Errorf("failed to write JSON to file: %w", err)
+					return fmt.Errorf("failed to write ruleset to file: %w", err)
 				}
 				logger.Info("Export completed successfully.", "output", output)
 			}
@@ -72,6 +120,12 @@ func NewExportCmd() *cobra.Command {
 	f := cmd.Flags()
 	f.StringVar(&owner, "owner", "", "Specify the organization name")
 	f.StringVarP(&output, "output", "o", "", "Output file path (default: stdout)")
+	cmdutil.StringEnumFlag(cmd, &format, "format", "", "", rulekit.Formats, "Output file format (default: by output file extension, otherwise json)")
+	f.BoolVar(&all, "all", false, "Export every ruleset to the directory specified by --dir")
+	f.StringVarP(&dir, "dir", "d", "", "Output directory for --all")
+	f.BoolVar(&includeRepos, "include-repos", false, "With --all, also export the repository rulesets of every repository in the organization")
+	cmd.MarkFlagsRequiredTogether("all", "dir")
+	cmd.MarkFlagsMutuallyExclusive("dir", "output")
 
 	return cmd
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
 	"github.com/srz-zumix/go-gh-extension/pkg/render"
@@ -20,11 +21,12 @@ type GetOptions struct {
 func NewGetCmd() *cobra.Command {
 	var opts GetOptions
 	var owner string
+	var includesParent bool
 
 	cmd := &cobra.Command{
 		Use:   "get <ruleset-id>",
 		Short: "Get an organization ruleset",
-		Long:  `Get detailed information about a specific organization ruleset by its ID. If org is not specified, the current repository's organization will be used.`,
+		Long:  `Get detailed information about a specific organization ruleset by its ID. Use --includes-parent to also look up the enterprise rulesets that apply to the repositories of the organization. If org is not specified, the current repository's organization will be used.`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
@@ -43,7 +45,7 @@ func NewGetCmd() *cobra.Command {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
 
-			ruleset, err := gh.GetOrgRuleset(ctx, client, repository, rulesetID)
+			ruleset, err := rulekit.GetOrgRuleset(ctx, client, repository, rulesetID, includesParent)
 			if err != nil {
 				return fmt.Errorf("failed to get organization ruleset: %w", err)
 			}
@@ -56,6 +58,7 @@ func NewGetCmd() *cobra.Command {
 
 	f := cmd.Flags()
 	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.BoolVarP(&includesParent, "includes-parent", "p", false, "Include parent rulesets")
 	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
 
 	return cmd
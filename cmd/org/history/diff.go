@@ -0,0 +1,85 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type DiffOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewDiffCmd returns a new cobra.Command for comparing two versions of an organization ruleset
+func NewDiffCmd() *cobra.Command {
+	var opts DiffOptions
+	var owner string
+	var unified bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "diff <ruleset> <version> <version>",
+		Short: "Compare two versions of an organization ruleset",
+		Long:  `Compare two versions of an organization ruleset, specified by ID or name, and report their semantic differences from the first version to the second. Each version is a version ID from the history, or 'current' for the live ruleset. Use --unified to show a unified diff instead of the list of changes. If org is not specified, the current repository's organization will be used.`,
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			ruleset, err := rulekit.FindRuleset(ctx, client, repository, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find organization ruleset: %w", err)
+			}
+
+			before, err := rulekit.ExportRulesetVersion(ctx, client, repository, ruleset, args[1])
+			if err != nil {
+				return fmt.Errorf("failed to get ruleset version %s: %w", args[1], err)
+			}
+			after, err := rulekit.ExportRulesetVersion(ctx, client, repository, ruleset, args[2])
+			if err != nil {
+				return fmt.Errorf("failed to get ruleset version %s: %w", args[2], err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			if unified {
+				diff, err := rulekit.UnifiedDiff(before, after, "version "+args[1], "version "+args[2])
+				if err != nil {
+					return fmt.Errorf("failed to compute unified diff: %w", err)
+				}
+				renderer.RenderUnifiedDiff(diff)
+				return nil
+			}
+
+			changes, err := rulekit.DiffConfig(before, after)
+			if err != nil {
+				return fmt.Errorf("failed to compare ruleset versions: %w", err)
+			}
+			renderer.RenderChanges(changes)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.BoolVarP(&unified, "unified", "u", false, "Show a unified diff instead of the list of changes")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in diff output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+// NewShowCmd returns a new cobra.Command for printing a version of an organization ruleset
+func NewShowCmd() *cobra.Command {
+	var owner string
+	var output string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "show <ruleset> <version>",
+		Short: "Print a version of an organization ruleset",
+		Long:  `Print a past version of an organization ruleset, specified by ID or name, in the export format. The version is a version ID from the history, or 'current' for the live ruleset. The format is chosen by --format or by the extension of the output file, and defaults to JSON. If org is not specified, the current repository's organization will be used.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			ruleset, err := rulekit.FindRuleset(ctx, client, repository, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find organization ruleset: %w", err)
+			}
+
+			config, err := rulekit.ExportRulesetVersion(ctx, client, repository, ruleset, args[1])
+			if err != nil {
+				return fmt.Errorf("failed to get ruleset version: %w", err)
+			}
+
+			if output == "" || output == "-" {
+				data, err := rulekit.MarshalConfig(config, rulekit.ResolveFormat(format, ""))
+				if err != nil {
+					return fmt.Errorf("failed to marshal ruleset: %w", err)
+				}
+				fmt.Print(string(data))
+				return nil
+			}
+			if err := rulekit.WriteConfigFile(output, config, rulekit.ResolveFormat(format, output)); err != nil {
+				return fmt.Errorf("failed to write ruleset to file: %w", err)
+			}
+			logger.Info("Export completed successfully.", "output", output)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.StringVarP(&output, "output", "o", "", "Output file path (default: stdout)")
+	cmdutil.StringEnumFlag(cmd, &format, "format", "", "", rulekit.Formats, "Output file format (default: by output file extension, otherwise json)")
+
+	return cmd
+}
@@ -13,8 +13,12 @@ func NewInsightCmd() *cobra.Command {
 		Long:  `Commands to view organization rule suite insights and evaluations`,
 	}
 
+	cmd.AddCommand(insight.NewArchiveCmd())
 	cmd.AddCommand(insight.NewGetCmd())
 	cmd.AddCommand(insight.NewListCmd())
+	cmd.AddCommand(insight.NewRollupCmd())
+	cmd.AddCommand(insight.NewStatsCmd())
+	cmd.AddCommand(insight.NewWatchCmd())
 
 	return cmd
 }
@@ -0,0 +1,52 @@
+package insight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+// NewArchiveCmd returns a new cobra.Command for archiving organization rule suites locally
+func NewArchiveCmd() *cobra.Command {
+	var owner string
+
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Archive organization rule suites locally",
+		Long:  `Fetch the rule suites of an organization that are not archived yet, along with their rule evaluations, and append them to a local archive under $GH_RULE_KIT_INSIGHT_DIR, or gh-rule-kit/insights under $XDG_STATE_HOME (default: ~/.local/state), in HOST/OWNER/rule-suites.jsonl. If org is not specified, the current repository's organization will be used. GitHub only lists rule suites of up to a month back, so run this at least monthly to keep the archive complete. Use --from and --to flags of list and stats commands to query the archive.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			ghClient, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			archive, err := rulekit.OpenRuleSuiteArchive(ghClient, repository)
+			if err != nil {
+				return err
+			}
+			added, total, err := rulekit.ArchiveRuleSuites(ctx, ghClient, repository, archive)
+			if err != nil {
+				return fmt.Errorf("failed to archive organization rule suites: %w", err)
+			}
+			logger.Info("Successfully archived rule suites.", "added", added, "total", total, "path", archive.Path())
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+
+	return cmd
+}
@@ -0,0 +1,86 @@
+package insight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+type RollupOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewRollupCmd returns a new cobra.Command for rolling organization rule suites up by repository
+func NewRollupCmd() *cobra.Command {
+	var opts RollupOptions
+	var owner string
+	var ref string
+	var timePeriod string
+	var actorName string
+	var result string
+	var top int
+	var drillDown int
+	var repos []string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "rollup",
+		Short: "Roll organization rule suites up by repository",
+		Long:  `Group the rule suites of an organization by repository and show how often they pass, fail and are bypassed, most failures and bypasses first. If org is not specified, the current repository's organization will be used. Use --drill-down flag to drill into the given number of top offending repositories, or --repo flag to drill into specific repositories: their rule suites are fetched one by one from the repository API to show the rulesets and rules of each repository and the rules that cause the most blocks across them. Use --limit flag to cap the number of rule suites fetched per repository.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			ghClient, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			listOpts := &gh.ListRuleSuitesOptions{
+				Ref:             ref,
+				TimePeriod:      timePeriod,
+				ActorName:       actorName,
+				RuleSuiteResult: result,
+			}
+
+			ruleSuites, err := gh.ListOrgRuleSuites(ctx, ghClient, repository, listOpts)
+			if err != nil {
+				return fmt.Errorf("failed to list organization rule suites: %w", err)
+			}
+
+			rollup := rulekit.RollupOrgRuleSuites(ruleSuites, top)
+			targets := append(rollup.TopRepositories(drillDown), repos...)
+			if len(targets) > 0 {
+				rollup.DrillDown(ctx, ghClient, repository, ruleSuites, targets, limit, top)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.RenderOrgRollup(rollup)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.StringVar(&ref, "ref", "", "Filter by ref name (e.g., 'main', 'refs/heads/main')")
+	f.StringVar(&timePeriod, "time-period", "", "Filter by time period (e.g., 'hour', 'day', 'week', 'month')")
+	f.StringVar(&actorName, "actor-name", "", "Filter by actor name")
+	f.StringVar(&result, "result", "", "Filter by rule suite result (e.g., 'pass', 'fail', 'bypass')")
+	f.IntVar(&drillDown, "drill-down", 0, "Drill into the given number of repositories with the most failures and bypasses")
+	f.IntVar(&limit, "limit", 0, "Maximum number of most recent rule suites to fetch per repository drilled into (0 for all)")
+	f.StringSliceVar(&repos, "repo", nil, "Drill into the given repositories (name without owner)")
+	f.IntVar(&top, "top", 0, "Show only the given number of repositories and rules with the most failures and bypasses (0 for all)")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
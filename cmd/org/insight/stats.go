@@ -0,0 +1,108 @@
+package insight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+type StatsOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewStatsCmd returns a new cobra.Command for aggregating organization rule suites
+func NewStatsCmd() *cobra.Command {
+	var opts StatsOptions
+	var owner string
+	var ref string
+	var timePeriod string
+	var from string
+	var to string
+	var actorName string
+	var result string
+	var by []string
+	var top int
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show organization rule suite statistics",
+		Long:  `Show how often rule suites of an organization pass, fail and are bypassed over the chosen period, per repository, ruleset, rule type, actor and ref. If org is not specified, the current repository's organization will be used. Repository, actor and ref count rule suites; ruleset and rule count rule evaluations, where a failed evaluation of a bypassed rule suite counts as a bypass. Rule evaluations are fetched for each rule suite, so use --limit flag to cap the number of rule suites on busy organizations, or --by flag to select dimensions that do not need them.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dimensions, err := rulekit.ParseInsightDimensions(by, rulekit.OrgInsightDimensions)
+			if err != nil {
+				return err
+			}
+
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			ghClient, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			listOpts := &gh.ListRuleSuitesOptions{
+				Ref:             ref,
+				TimePeriod:      timePeriod,
+				ActorName:       actorName,
+				RuleSuiteResult: result,
+			}
+
+			archived := from != "" || to != ""
+			var ruleSuites []*gh.RuleSuite
+			if archived {
+				query, err := rulekit.NewRuleSuiteQuery(from, to, listOpts)
+				if err != nil {
+					return err
+				}
+				ruleSuites, err = rulekit.QueryRuleSuiteArchive(ghClient, repository, query)
+				if err != nil {
+					return fmt.Errorf("failed to query archived organization rule suites: %w", err)
+				}
+			} else {
+				ruleSuites, err = gh.ListOrgRuleSuites(ctx, ghClient, repository, listOpts)
+				if err != nil {
+					return fmt.Errorf("failed to list organization rule suites: %w", err)
+				}
+			}
+			if limit > 0 && len(ruleSuites) > limit {
+				ruleSuites = ruleSuites[:limit]
+			}
+			if !archived && rulekit.NeedsRuleEvaluations(dimensions) {
+				ruleSuites = rulekit.GetRuleSuiteDetails(ctx, ghClient, repository, ruleSuites)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.RenderInsightStats(rulekit.AggregateRuleSuites(ruleSuites, dimensions, top))
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.StringVar(&ref, "ref", "", "Filter by ref name (e.g., 'main', 'refs/heads/main')")
+	f.StringVar(&timePeriod, "time-period", "", "Filter by time period (e.g., 'hour', 'day', 'week', 'month')")
+	f.StringVar(&from, "from", "", "Query the local archive for rule suites pushed on or after the date (YYYY-MM-DD or RFC 3339)")
+	f.StringVar(&to, "to", "", "Query the local archive for rule suites pushed on or before the date (YYYY-MM-DD or RFC 3339)")
+	f.StringVar(&actorName, "actor-name", "", "Filter by actor name")
+	f.StringVar(&result, "result", "", "Filter by rule suite result (e.g., 'pass', 'fail', 'bypass')")
+	f.StringSliceVar(&by, "by", nil, fmt.Sprintf("Dimensions to group by: %v (default: repository, ruleset, rule, actor and ref)", rulekit.InsightDimensions))
+	f.IntVar(&limit, "limit", 0, "Maximum number of most recent rule suites to aggregate (0 for all)")
+	f.IntVar(&top, "top", 0, "Show only the given number of keys with the most failures and bypasses per dimension (0 for all)")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+	cmd.MarkFlagsMutuallyExclusive("time-period", "from")
+	cmd.MarkFlagsMutuallyExclusive("time-period", "to")
+
+	return cmd
+}
@@ -6,9 +6,10 @@ import (
 
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
-	"github.com/srz-zumix/go-gh-extension/pkg/render"
 )
 
 type ListOptions struct {
@@ -19,11 +20,12 @@ type ListOptions struct {
 func NewListCmd() *cobra.Command {
 	var opts ListOptions
 	var owner string
+	var listIncludesParent bool
 
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List organization rulesets",
-		Long:    `List all rulesets for an organization. If org is not specified, the current repository's organization will be used.`,
+		Long:    `List all rulesets for an organization. Use --includes-parent to also list the enterprise rulesets that apply to the repositories of the organization, with the source they are defined in; this lists the rulesets of every repository and may take a while. If org is not specified, the current repository's organization will be used.`,
 		Aliases: []string{"ls"},
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -38,19 +40,24 @@ func NewListCmd() *cobra.Command {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
 
-			rulesets, err := gh.ListOrgRulesets(ctx, client, repository)
+			rulesets, err := rulekit.ListOrgRulesets(ctx, client, repository, listIncludesParent)
 			if err != nil {
 				return fmt.Errorf("failed to list organization rulesets: %w", err)
 			}
 
-			renderer := render.NewRenderer(opts.Exporter)
-			renderer.RenderRepositoryRulesetsDefault(rulesets)
+			renderer := report.NewRenderer(opts.Exporter)
+			if listIncludesParent {
+				renderer.RenderRulesetsWithSource(rulesets)
+			} else {
+				renderer.RenderRepositoryRulesetsDefault(rulesets)
+			}
 			return nil
 		},
 	}
 
 	f := cmd.Flags()
 	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.BoolVarP(&listIncludesParent, "includes-parent", "p", false, "Include parent rulesets")
 	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
 
 	return cmd
@@ -5,20 +5,33 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/logger"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
 )
 
+type MigrateOptions struct {
+	Exporter cmdutil.Exporter
+}
+
 // NewMigrateCmd returns a new cobra.Command for migrating organization rulesets
 func NewMigrateCmd() *cobra.Command {
+	var opts MigrateOptions
 	var gitHubActionsAppID int64
+	var plan bool
+	var onConflict string
+	var actorMapFile string
+	var colorFlag string
 
 	cmd := &cobra.Command{
 		Use:   "migrate <[HOST/]src-org> <[HOST/]dst-org> [ruleset-id...]",
 		Short: "Migrate organization rulesets to another organization",
-		Long:  `Migrate organization rulesets from source organization to destination organization. If ruleset IDs are not specified, all rulesets will be migrated. Source organization is specified as the first argument, destination organization is specified as the second argument.`,
+		Long:  `Migrate organization rulesets from source organization to destination organization. If ruleset IDs are not specified, all rulesets will be migrated. Rulesets are matched with the destination by name, and --on-conflict decides what happens to an existing one: update it in place (default), skip it, replace it by deleting and recreating it, create the new one under a free name such as 'name (2)' with rename, or fail; skip and update make the migration safe to rerun after a partial failure. Use --actor-map to map bypass actors and the integrations of required status checks when IDs or names differ, for example between GitHub Enterprise Server and GitHub Enterprise Cloud. The file is JSON or YAML with an 'actors' list of entries with 'type' (Team, Integration, OrganizationAdmin, RepositoryRole, or DeployKey), 'source' and 'destination', each an ID or a name (team slug, app slug or repository role name); a destination of 'none' drops the actor. With --actor-map, actors without an entry are matched by team slug or role name, and the ruleset fails when an actor cannot be resolved instead of being dropped. Without --actor-map, teams are matched by slug and the ruleset also fails when a team is not found in the destination. Use --plan flag to show a field-level diff against the destination rulesets, matched by name, without writing them. The plan is computed from the rulesets as they would be imported, after mapping them with --actor-map. Source organization is specified as the first argument, destination organization is specified as the second argument.`,
 		Args:  cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			srcOrg := args[0]
@@ -49,6 +62,15 @@ func NewMigrateCmd() *cobra.Command {
 				return fmt.Errorf("failed to create GitHub client for destination organization: %w", err)
 			}
 
+			var resolver *rulekit.ActorResolver
+			if actorMapFile != "" {
+				actorMap, err := rulekit.LoadActorMap(actorMapFile)
+				if err != nil {
+					return fmt.Errorf("failed to load actor map: %w", err)
+				}
+				resolver = rulekit.NewActorResolver(actorMap, srcClient, srcRepository, dstClient, dstRepository)
+			}
+
 			var rulesetIDs []int64
 			if len(args) > 2 {
 				// Parse specified ruleset IDs
@@ -86,6 +108,8 @@ func NewMigrateCmd() *cobra.Command {
 
 			// Migrate each ruleset
 			successCount := 0
+			unsupportedCount := 0
+			var plans []*rulekit.Plan
 			for _, rulesetID := range rulesetIDs {
 				logger.Info("Migrating ruleset", "id", rulesetID)
 
@@ -95,19 +119,79 @@ func NewMigrateCmd() *cobra.Command {
 					logger.Error("Failed to export ruleset", "id", rulesetID, "error", err)
 					continue
 				}
+				name := migrateConfig.Ruleset.Name
+				var integrations rulekit.IntegrationMap
+				if resolver != nil {
+					if integrations, err = resolver.MapMigrateConfig(ctx, migrateConfig); err != nil {
+						logger.Error("Failed to map ruleset actors", "name", name, "error", err)
+						continue
+					}
+				}
+
+				if plan {
+					found, err := gh.FindRulesetByName(ctx, dstClient, dstRepository, migrateConfig.Ruleset.Name, false)
+					if err != nil {
+						logger.Error("Failed to find destination ruleset", "name", migrateConfig.Ruleset.Name, "error", err)
+						continue
+					}
+					found, ok, err := rulekit.ResolvePlanConflict(rulekit.ConflictStrategy(onConflict), found)
+					if err != nil {
+						logger.Error("Failed to plan ruleset", "name", migrateConfig.Ruleset.Name, "error", err)
+						continue
+					}
+					if !ok {
+						logger.Info("Skipped existing ruleset", "name", migrateConfig.Ruleset.Name)
+						successCount++
+						continue
+					}
+					current, err := rulekit.ExportCurrentRuleset(ctx, dstClient, dstRepository, found)
+					if err != nil {
+						logger.Error("Failed to get destination ruleset", "name", migrateConfig.Ruleset.Name, "error", err)
+						continue
+					}
+					p, err := rulekit.NewPlan(dstRepository.Owner, current, gh.ExportRuleset(migrateConfig.Ruleset))
+					if err != nil {
+						logger.Error("Failed to compute ruleset plan", "name", migrateConfig.Ruleset.Name, "error", err)
+						continue
+					}
+					plans = append(plans, p)
+					successCount++
+					continue
+				}
 
 				// Import ruleset to destination (handles team actor ID mapping)
-				createdRuleset, err := gh.ImportMigrateRuleset(ctx, dstClient, dstRepository, migrateConfig, gitHubActionsAppIDPtr)
+				createdRuleset, action, err := rulekit.ImportMigrateRuleset(ctx, dstClient, dstRepository, migrateConfig, rulekit.ConflictStrategy(onConflict), gitHubActionsAppIDPtr, integrations)
 				if err != nil {
-					logger.Error("Failed to import ruleset", "name", migrateConfig.Ruleset.Name, "error", err)
+					logger.Error("Failed to import ruleset", "name", name, "error", err)
+					continue
+				}
+				switch action {
+				case rulekit.MigrationActionUnsupported:
+					logger.Warn("Skipped ruleset not supported by the destination", "name", name)
+					unsupportedCount++
+					successCount++
+					continue
+				case rulekit.MigrationActionSkipped:
+					logger.Info("Skipped existing ruleset", "name", name)
+					successCount++
 					continue
 				}
 
-				logger.Info("Successfully migrated ruleset", "src_id", rulesetID, "dst_id", *createdRuleset.ID, "name", createdRuleset.Name)
+				logger.Info("Successfully migrated ruleset", "src_id", rulesetID, "dst_id", *createdRuleset.ID, "name", createdRuleset.Name, "action", action)
 				successCount++
 			}
 
-			logger.Info("Migration completed", "total", len(rulesetIDs), "success", successCount, "failed", len(rulesetIDs)-successCount)
+			if plan {
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans(plans)
+				if successCount < len(rulesetIDs) {
+					return fmt.Errorf("failed to plan %d rulesets", len(rulesetIDs)-successCount)
+				}
+				return nil
+			}
+
+			logger.Info("Migration completed", "total", len(rulesetIDs), "success", successCount, "unsupported", unsupportedCount, "failed", len(rulesetIDs)-successCount)
 
 			if successCount == 0 {
 				return fmt.Errorf("failed to migrate any rulesets")
@@ -118,7 +202,12 @@ func NewMigrateCmd() *cobra.Command {
 	}
 
 	f := cmd.Flags()
+	f.StringVar(&actorMapFile, "actor-map", "", "Map bypass actors and status check integrations with a JSON or YAML file, failing on actors that cannot be resolved")
 	f.Int64Var(&gitHubActionsAppID, "github-actions-app-id", 0, "The GitHub Actions App ID for integration mapping")
+	cmdutil.StringEnumFlag(cmd, &onConflict, "on-conflict", "", string(rulekit.ConflictUpdate), rulekit.ConflictStrategies, "What to do when a ruleset with the same name exists in the destination")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
 
 	return cmd
 }
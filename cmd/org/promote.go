@@ -0,0 +1,105 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+type PromoteOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewPromoteCmd returns a new cobra.Command for promoting an organization ruleset from evaluate to active enforcement
+func NewPromoteCmd() *cobra.Command {
+	var opts PromoteOptions
+	var owner string
+	var timePeriod string
+	var maxBlocked int
+	var limit int
+	var plan bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "promote <ruleset>",
+		Short: "Promote an organization ruleset from evaluate to active",
+		Long:  `Promote an organization ruleset in evaluate mode, specified by ID or name, to active enforcement based on what it would have blocked. The rule suites of the evaluation window are read to count the pushes the ruleset would have blocked, along with the affected repositories, actors, refs and rules, and the ruleset is switched to active only when the count does not exceed --max-blocked. A count cut short by --limit can only be shown, not used to promote. Confirmation is asked before the ruleset is updated; use --yes to skip the confirmation, which is required when not running in a terminal. The ruleset is backed up first. Use --plan flag, or read-only mode, to only show the impact. If org is not specified, the current repository's organization will be used.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			ruleset, err := rulekit.FindRuleset(ctx, client, repository, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find organization ruleset: %w", err)
+			}
+
+			impact, err := rulekit.AnalyzePromotion(ctx, client, repository, ruleset, &gh.ListRuleSuitesOptions{TimePeriod: timePeriod}, limit)
+			if err != nil {
+				return fmt.Errorf("failed to analyze ruleset promotion: %w", err)
+			}
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.RenderPromotionImpact(impact)
+
+			if impact.Blocked > maxBlocked {
+				return fmt.Errorf("ruleset '%s' would have blocked %d pushes, more than the allowed %d", ruleset.Name, impact.Blocked, maxBlocked)
+			}
+			if plan || guardrails.IsReadonly() {
+				return nil
+			}
+			if impact.Truncated {
+				return fmt.Errorf("only %d of %d rule suites with failed evaluations were inspected, remove --limit to promote the ruleset", impact.Inspected, impact.Failed)
+			}
+			if !yes {
+				if !term.IsTerminal(os.Stdin) {
+					return fmt.Errorf("confirmation required, use --yes to promote without prompting")
+				}
+				confirmed, err := prompter.New(os.Stdin, os.Stdout, os.Stderr).Confirm(fmt.Sprintf("Switch ruleset '%s' to active?", ruleset.Name), false)
+				if err != nil {
+					return fmt.Errorf("failed to confirm promotion: %w", err)
+				}
+				if !confirmed {
+					logger.Info("Promotion canceled")
+					return nil
+				}
+			}
+
+			promoted, err := rulekit.PromoteRuleset(ctx, client, repository, ruleset)
+			if err != nil {
+				return fmt.Errorf("failed to promote organization ruleset: %w", err)
+			}
+			logger.Info("Successfully promoted ruleset.", "rulesetID", promoted.GetID(), "rulesetName", promoted.Name, "enforcement", promoted.Enforcement)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.StringVar(&timePeriod, "time-period", "week", "Evaluation window to read rule suites from (e.g., 'hour', 'day', 'week', 'month')")
+	f.IntVar(&maxBlocked, "max-blocked", 0, "Maximum number of pushes the ruleset may have blocked to be promoted")
+	f.IntVar(&limit, "limit", 0, "Maximum number of most recent rule suites with failed evaluations to inspect, which only allows showing the impact (0 for all)")
+	f.BoolVar(&plan, "plan", false, "Show the impact without promoting the ruleset")
+	f.BoolVarP(&yes, "yes", "y", false, "Promote without asking for confirmation")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
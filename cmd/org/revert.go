@@ -0,0 +1,101 @@
+package org
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+// NewRevertCmd returns a new cobra.Command for reverting an organization ruleset to a previous version
+func NewRevertCmd() *cobra.Command {
+	var owner string
+	var version string
+	var yes bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "revert <ruleset> --to <version>",
+		Short: "Revert an organization ruleset to a previous version",
+		Long:  `Revert an organization ruleset, specified by ID or name, to a previous version from its history. The field-level diff from the live ruleset to the version is shown and confirmation is asked before the ruleset is updated; use --yes to skip the confirmation, which is required when not running in a terminal. The live ruleset is backed up first, so the revert itself can be undone with the restore command. In read-only mode only the diff is shown. If org is not specified, the current repository's organization will be used.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			ruleset, err := rulekit.FindRuleset(ctx, client, repository, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find organization ruleset: %w", err)
+			}
+
+			config, err := rulekit.ExportRulesetVersion(ctx, client, repository, ruleset, version)
+			if err != nil {
+				return fmt.Errorf("failed to get ruleset version: %w", err)
+			}
+
+			p, err := rulekit.NewPlan(repository.Owner, gh.ExportRuleset(ruleset), config)
+			if err != nil {
+				return fmt.Errorf("failed to compute ruleset plan: %w", err)
+			}
+			renderer := report.NewRenderer(nil)
+			renderer.SetColor(colorFlag)
+			renderer.RenderPlans([]*rulekit.Plan{p})
+
+			if p.Action == rulekit.ApplyActionNoChange {
+				logger.Info("Ruleset already matches the version", "name", ruleset.Name, "version", version)
+				return nil
+			}
+			if guardrails.IsReadonly() {
+				return nil
+			}
+			if !yes {
+				if !term.IsTerminal(os.Stdin) {
+					return fmt.Errorf("confirmation required, use --yes to revert without prompting")
+				}
+				confirmed, err := prompter.New(os.Stdin, os.Stdout, os.Stderr).Confirm(fmt.Sprintf("Revert ruleset '%s' to version %s?", ruleset.Name, version), false)
+				if err != nil {
+					return fmt.Errorf("failed to confirm revert: %w", err)
+				}
+				if !confirmed {
+					logger.Info("Revert canceled")
+					return nil
+				}
+			}
+
+			reverted, err := rulekit.RevertRuleset(ctx, client, repository, ruleset.GetID(), config)
+			if err != nil {
+				return fmt.Errorf("failed to revert organization ruleset: %w", err)
+			}
+			logger.Info("Successfully reverted ruleset.", "rulesetID", reverted.GetID(), "rulesetName", reverted.Name, "version", version)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.StringVar(&version, "to", "", "The version ID to revert to, as listed by history")
+	f.BoolVarP(&yes, "yes", "y", false, "Revert without asking for confirmation")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
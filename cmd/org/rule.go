@@ -0,0 +1,21 @@
+package org
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/cmd/org/rule"
+)
+
+// NewRuleCmd returns a new cobra.Command for editing the rules of an organization ruleset
+func NewRuleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rule",
+		Short: "Edit the rules of an organization ruleset",
+		Long:  `Commands to add, remove and change the rules of an organization ruleset in place`,
+	}
+
+	cmd.AddCommand(rule.NewAddCmd())
+	cmd.AddCommand(rule.NewRemoveCmd())
+	cmd.AddCommand(rule.NewSetParamCmd())
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type AddOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewAddCmd returns a new cobra.Command for adding a rule to an organization ruleset
+func NewAddCmd() *cobra.Command {
+	var opts AddOptions
+	var owner string
+	var params []string
+	var plan bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "add <ruleset> <type>",
+		Short: "Add a rule to an organization ruleset",
+		Long:  `Add a rule of the given type (e.g., 'required_linear_history', 'pull_request') to an organization ruleset, specified by ID or name. Rule parameters are given with --param as 'name=value', where the value is parsed as JSON when possible (e.g., 'required_approving_review_count=2', 'required_status_checks=[{"context":"ci"}]'), and 'name+=value' adds a value to a list parameter. The ruleset is validated after the change and backed up before it is updated. Use --plan flag to show a field-level diff of the change without writing it. If org is not specified, the current repository's organization will be used.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryOwner(owner))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			p, err := rulekit.PlanRulesetEdit(ctx, client, repository, repository.Owner, args[0], rulekit.AddRule(args[1], params))
+			if err != nil {
+				return fmt.Errorf("failed to add rule: %w", err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderPlans([]*rulekit.Plan{p})
+			if plan || p.Action == rulekit.ApplyActionNoChange {
+				return nil
+			}
+			if guardrails.IsReadonly() {
+				return fmt.Errorf("cannot edit ruleset in read-only mode")
+			}
+
+			updated, err := rulekit.ApplyRulesetEdit(ctx, client, repository, p)
+			if err != nil {
+				return fmt.Errorf("failed to update organization ruleset: %w", err)
+			}
+			logger.Info("Successfully added rule.", "rulesetID", updated.GetID(), "rulesetName", updated.Name, "type", args[1])
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&owner, "owner", "", "Specify the organization name")
+	f.StringArrayVar(&params, "param", nil, "Rule parameter as 'name=value' or 'name+=value' (can be repeated)")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
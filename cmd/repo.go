@@ -13,13 +13,23 @@ func NewRepoCmd() *cobra.Command {
 		Long:  `Commands to manage repository rulesets`,
 	}
 
+	cmd.AddCommand(repo.NewApplyCmd())
+	cmd.AddCommand(repo.NewBypassCmd())
+	cmd.AddCommand(repo.NewConditionCmd())
+	cmd.AddCommand(repo.NewConvertProtectionCmd())
 	cmd.AddCommand(repo.NewDeleteCmd())
+	cmd.AddCommand(repo.NewDriftCmd())
+	cmd.AddCommand(repo.NewEnforceCmd())
 	cmd.AddCommand(repo.NewExportCmd())
 	cmd.AddCommand(repo.NewGetCmd())
+	cmd.AddCommand(repo.NewHistoryCmd())
 	cmd.AddCommand(repo.NewImportCmd())
 	cmd.AddCommand(repo.NewInsightCmd())
 	cmd.AddCommand(repo.NewListCmd())
 	cmd.AddCommand(repo.NewMigrateCmd())
+	cmd.AddCommand(repo.NewPromoteCmd())
+	cmd.AddCommand(repo.NewRevertCmd())
+	cmd.AddCommand(repo.NewRuleCmd())
 
 	return cmd
 }
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/cmd/repo/bypass"
+)
+
+// NewBypassCmd returns a new cobra.Command for editing the bypass actors of a repository ruleset
+func NewBypassCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bypass",
+		Short: "Edit the bypass actors of a repository ruleset",
+		Long:  `Commands to add and remove the bypass actors of a repository ruleset in place`,
+	}
+
+	cmd.AddCommand(bypass.NewAddCmd())
+	cmd.AddCommand(bypass.NewRemoveCmd())
+
+	return cmd
+}
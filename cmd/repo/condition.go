@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/cmd/repo/condition"
+)
+
+// NewConditionCmd returns a new cobra.Command for editing the conditions of a repository ruleset
+func NewConditionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "condition",
+		Short: "Edit the conditions of a repository ruleset",
+		Long:  `Commands to edit the ref patterns a repository ruleset includes and excludes in place`,
+	}
+
+	cmd.AddCommand(condition.NewExcludeCmd())
+	cmd.AddCommand(condition.NewIncludeCmd())
+
+	return cmd
+}
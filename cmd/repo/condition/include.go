@@ -0,0 +1,80 @@
+package condition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type IncludeOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewIncludeCmd returns a new cobra.Command for editing the ref patterns a repository ruleset includes
+func NewIncludeCmd() *cobra.Command {
+	var opts IncludeOptions
+	var repo string
+	var remove bool
+	var plan bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "include <ruleset> <pattern>...",
+		Short: "Add ref patterns a repository ruleset includes",
+		Long:  `Add ref patterns (e.g., 'refs/heads/main', 'refs/heads/release/*', '~DEFAULT_BRANCH', '~ALL') to the include list of the ref name condition of a repository ruleset, specified by ID or name. Patterns already in the list are left as they are. Use --remove flag to remove the patterns from the list instead. The ruleset is backed up before it is updated. Use --plan flag to show a field-level diff of the change without writing it. If repo is not specified, the current repository will be used.`,
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			edit := rulekit.EditConditionPatterns(rulekit.ConditionRefName, "include", args[1:], remove)
+			p, err := rulekit.PlanRulesetEdit(ctx, client, repository, parser.GetRepositoryFullName(repository), args[0], edit)
+			if err != nil {
+				return fmt.Errorf("failed to edit included patterns: %w", err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderPlans([]*rulekit.Plan{p})
+			if plan || p.Action == rulekit.ApplyActionNoChange {
+				return nil
+			}
+			if guardrails.IsReadonly() {
+				return fmt.Errorf("cannot edit ruleset in read-only mode")
+			}
+
+			updated, err := rulekit.ApplyRulesetEdit(ctx, client, repository, p)
+			if err != nil {
+				return fmt.Errorf("failed to update repository ruleset: %w", err)
+			}
+			logger.Info("Successfully edited included patterns.", "rulesetID", updated.GetID(), "rulesetName", updated.Name, "patterns", args[1:], "removed", remove)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	f.BoolVar(&remove, "remove", false, "Remove the patterns instead of adding them")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
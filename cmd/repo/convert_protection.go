@@ -0,0 +1,134 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type ConvertProtectionOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewConvertProtectionCmd returns a new cobra.Command for converting classic branch protection into rulesets
+func NewConvertProtectionCmd() *cobra.Command {
+	var opts ConvertProtectionOptions
+	var repo string
+	var branches []string
+	var output string
+	var format string
+	var importRulesets bool
+	var plan bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "convert-protection",
+		Short: "Convert classic branch protection into rulesets",
+		Long:  `Convert the classic branch protection of repository branches into equivalent rulesets, one per branch. Required reviews, conversation resolution, status checks, linear history, signed commits, force pushes, deletions, branch locking and branch creation are converted. When protection is not enforced for administrators, the admin repository role is added as a bypass actor. Settings that have no ruleset equivalent, such as push restrictions, review dismissal restrictions and pull request bypass allowances, are reported as warnings. If --branch is not specified, every protected branch is converted. The rulesets are written to stdout or to --output as a single ruleset, a JSON array or a multi-document YAML. Use --import to import them like 'repo import --create-if-none', or --plan to show the changes the import would make; with --plan, --format json prints the plan as JSON. If repo is not specified, the current repository will be used.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			conversions, err := rulekit.ConvertBranchProtections(ctx, client, repository, branches)
+			if err != nil {
+				return fmt.Errorf("failed to convert branch protection: %w", err)
+			}
+			if len(conversions) == 0 {
+				logger.Info("No protected branches to convert", "repository", parser.GetRepositoryFullName(repository))
+				return nil
+			}
+
+			configs := make([]*gh.RepositoryRulesetConfig, 0, len(conversions))
+			for _, conversion := range conversions {
+				for _, setting := range conversion.Unsupported {
+					logger.Warn("Setting has no ruleset equivalent and was not converted", "branch", conversion.Branch, "setting", setting)
+				}
+				configs = append(configs, conversion.Config)
+			}
+
+			if plan {
+				switch rulekit.Format(format) {
+				case rulekit.FormatJSON:
+					opts.Exporter = cmdutil.NewJSONExporter()
+				case rulekit.FormatYAML:
+					return fmt.Errorf("--format %s cannot be used with --plan", format)
+				}
+				var plans []*rulekit.Plan
+				for _, config := range configs {
+					p, err := rulekit.PlanImport(ctx, client, repository, parser.GetRepositoryFullName(repository), config, true)
+					if err != nil {
+						return fmt.Errorf("failed to plan repository ruleset: %w", err)
+					}
+					plans = append(plans, p)
+				}
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans(plans)
+				return nil
+			}
+
+			if importRulesets {
+				successCount := 0
+				for _, config := range configs {
+					resultRuleset, action, err := rulekit.ImportConfig(ctx, client, repository, config, true)
+					if err != nil {
+						logger.Error("Failed to import ruleset", "name", config.Name, "error", err)
+						continue
+					}
+					logger.Info("Successfully imported ruleset.", "action", action, "rulesetID", *resultRuleset.ID, "rulesetName", resultRuleset.Name, "repository", parser.GetRepositoryFullName(repository))
+					successCount++
+				}
+				if successCount < len(configs) {
+					return fmt.Errorf("failed to import %d of %d rulesets", len(configs)-successCount, len(configs))
+				}
+				return nil
+			}
+
+			data, err := rulekit.MarshalConfigs(configs, rulekit.ResolveFormat(format, output))
+			if err != nil {
+				return fmt.Errorf("failed to marshal rulesets: %w", err)
+			}
+			if output == "" || output == "-" {
+				fmt.Print(string(data))
+				return nil
+			}
+			if err := os.WriteFile(output, data, 0644); err != nil {
+				return fmt.Errorf("failed to write rulesets to file: %w", err)
+			}
+			logger.Info("Conversion completed successfully.", "output", output, "count", len(configs))
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	f.StringArrayVarP(&branches, "branch", "b", nil, "Branch whose protection is converted (can be specified multiple times)")
+	f.StringVarP(&output, "output", "o", "", "Output file path (default: stdout)")
+	cmdutil.StringEnumFlag(cmd, &format, "format", "", "", rulekit.Formats, "Output file format, or the plan format with --plan (default: by output file extension, otherwise json)")
+	f.BoolVar(&importRulesets, "import", false, "Import the converted rulesets, creating them if they do not exist")
+	f.BoolVar(&plan, "plan", false, "Show the changes that importing the converted rulesets would make without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmd.MarkFlagsMutuallyExclusive("import", "plan")
+	cmd.MarkFlagsMutuallyExclusive("output", "import")
+	cmd.MarkFlagsMutuallyExclusive("output", "plan")
+
+	return cmd
+}
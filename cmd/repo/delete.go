@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/logger"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
@@ -18,7 +19,7 @@ func NewDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete <ruleset-id>",
 		Short: "Delete a repository ruleset",
-		Long:  `Delete a specific repository ruleset by its ID. If repo is not specified, the current repository will be used.`,
+		Long:  `Delete a specific repository ruleset by its ID. If repo is not specified, the current repository will be used. The ruleset is backed up to a snapshot first, which can be put back with the restore command.`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
@@ -37,7 +38,7 @@ func NewDeleteCmd() *cobra.Command {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
 
-			err = gh.DeleteRepositoryRuleset(ctx, client, repository, rulesetID)
+			err = rulekit.RemoveRuleset(ctx, client, repository, rulesetID)
 			if err != nil {
 				return fmt.Errorf("failed to delete repository ruleset: %w", err)
 			}
@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/actions"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type DriftOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewDriftCmd returns a new cobra.Command for detecting drift between ruleset files and a repository
+func NewDriftCmd() *cobra.Command {
+	var opts DriftOptions
+	var repo string
+	var ignoreUnmanaged bool
+	var colorFlag string
+	var templateVars []string
+	var varsFile string
+
+	cmd := &cobra.Command{
+		Use:   "drift <dir>",
+		Short: "Detect drift between ruleset files and a repository",
+		Long:  `Compare every ruleset file (.json, .yaml or .yml) in a directory with the live rulesets of a repository and report rulesets that were changed, are missing on GitHub, or exist on GitHub without a file. Use --ignore-unmanaged to skip live rulesets that have no file. Exits with 0 when in sync, 2 when drifted and 1 on error. When running on GitHub Actions, an error annotation is emitted for each drifted ruleset. If repo is not specified, the current repository will be used. Ruleset files may be Go templates filled with --var and --vars-file, see Ruleset Templates in the README.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := args[0]
+
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			vars, err := rulekit.LoadTemplateVars(varsFile, templateVars)
+			if err != nil {
+				return fmt.Errorf("failed to read template variables: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			td := rulekit.NewTemplateData(ctx, client, repository, vars)
+			files, err := rulekit.LoadConfigDir(dir, td)
+			if err != nil {
+				return fmt.Errorf("failed to read ruleset files: %w", err)
+			}
+
+			drifts, err := rulekit.DetectDrift(ctx, client, repository, files, !ignoreUnmanaged)
+			if err != nil {
+				return fmt.Errorf("failed to detect repository ruleset drift: %w", err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderDrifts(drifts)
+			if actions.IsRunsOn() {
+				renderer.RenderDriftAnnotations(drifts)
+			}
+
+			if drifted := rulekit.CountDrift(drifts); drifted > 0 {
+				cmd.SilenceUsage = true
+				return rulekit.NewExitCodeError(rulekit.ExitCodeDrifted, "%d rulesets have drifted", drifted)
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	f.BoolVar(&ignoreUnmanaged, "ignore-unmanaged", false, "Ignore live rulesets that have no matching file")
+	f.StringArrayVar(&templateVars, "var", nil, "Set a template variable in the format 'key=value' (can be specified multiple times)")
+	f.StringVar(&varsFile, "vars-file", "", "Read template variables from a JSON or YAML file")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in drift output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
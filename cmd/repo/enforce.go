@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+type EnforceOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewEnforceCmd returns a new cobra.Command for changing the enforcement of repository rulesets
+func NewEnforceCmd() *cobra.Command {
+	var opts EnforceOptions
+	var repo string
+	var namePattern string
+	var current string
+	var target string
+	var plan bool
+
+	cmd := &cobra.Command{
+		Use:   "enforce [<ruleset>...] <active|evaluate|disabled>",
+		Short: "Change the enforcement of repository rulesets",
+		Long:  `Change only the enforcement of repository rulesets, specified by ID or name, and of the rulesets matching every given filter (--name, --current, --target). Each ruleset is backed up before it is updated, and the previous enforcement of each ruleset is reported so the change can be undone by running the command again with it. Use --plan flag to show the changes without writing them; in read-only mode only the changes are shown. If repo is not specified, the current repository will be used.`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			enforcement := args[len(args)-1]
+			selector := &rulekit.RulesetSelector{
+				Rulesets:    args[:len(args)-1],
+				NamePattern: namePattern,
+				Enforcement: current,
+				Target:      target,
+			}
+			changes, err := rulekit.PlanEnforcement(ctx, client, repository, selector, enforcement)
+			if err != nil {
+				return fmt.Errorf("failed to plan enforcement change: %w", err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.RenderEnforcementChanges(changes)
+			if plan {
+				return nil
+			}
+			if guardrails.IsReadonly() {
+				return nil
+			}
+
+			applied, err := rulekit.ApplyEnforcement(ctx, client, repository, changes)
+			if err != nil {
+				if len(applied) > 0 {
+					logger.Warn("Some rulesets were changed before the failure, run enforce with their previous enforcement to undo them", "updated", len(applied))
+					renderer.RenderEnforcementChanges(applied)
+				}
+				return fmt.Errorf("failed to change enforcement of repository rulesets: %w", err)
+			}
+			logger.Info("Successfully changed enforcement.", "enforcement", enforcement, "updated", len(applied))
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	f.StringVar(&namePattern, "name", "", "Select rulesets whose name matches the pattern (e.g., 'release-*')")
+	cmdutil.StringEnumFlag(cmd, &current, "current", "", "", rulekit.RulesetEnforcements, "Select rulesets with the given current enforcement")
+	cmdutil.StringEnumFlag(cmd, &target, "target", "", "", []string{"branch", "tag", "push"}, "Select rulesets with the given target")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
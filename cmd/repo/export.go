@@ -2,12 +2,12 @@ package repo
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"strconv"
 
+	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/logger"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
@@ -17,19 +17,22 @@ import (
 func NewExportCmd() *cobra.Command {
 	var repo string
 	var output string
+	var format string
 	var includesParent bool
+	var all bool
+	var dir string
 
 	cmd := &cobra.Command{
-		Use:   "export <ruleset-id>",
-		Short: "Export a repository ruleset to JSON file",
-		Long:  `Export a specific repository ruleset by its ID to a JSON file. If repo is not specified, the current repository will be used. The exported JSON can be used for backup or to import into another repository.`,
-		Args:  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
-			if err != nil {
-				return fmt.Errorf("invalid ruleset ID: %w", err)
+		Use:   "export [<ruleset-id>]",
+		Short: "Export repository rulesets to JSON or YAML files",
+		Long:  `Export a specific repository ruleset by its ID to a JSON or YAML file. The format is chosen by --format or by the extension of the output file, and defaults to JSON. When overwriting an existing YAML file, its comments are kept for the fields that still exist. Use --all with --dir to export every ruleset to its own file named after the slug of the ruleset name; --all only exports the rulesets of the repository and cannot be combined with --includes-parent. If repo is not specified, the current repository will be used. The exported file can be used for backup or to import into another repository.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if all {
+				return cobra.NoArgs(cmd, args)
 			}
-
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
 			repository, err := parser.Repository(parser.RepositoryInput(repo))
 			if err != nil {
 				return fmt.Errorf("error parsing repository: %w", err)
@@ -41,6 +44,20 @@ func NewExportCmd() *cobra.Command {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
 
+			if all {
+				paths, err := rulekit.ExportRulesets(ctx, client, repository, dir, rulekit.ResolveFormat(format, ""))
+				if err != nil {
+					return fmt.Errorf("failed to export repository rulesets: %w", err)
+				}
+				logger.Info("Export completed successfully.", "dir", dir, "count", len(paths), "repository", parser.GetRepositoryFullName(repository))
+				return nil
+			}
+
+			rulesetID, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid ruleset ID: %w", err)
+			}
+
 			ruleset, err := gh.GetRepositoryRuleset(ctx, client, repository, rulesetID, includesParent)
 			if err != nil {
 				return fmt.Errorf("failed to get repository ruleset: %w", err)
@@ -48,20 +65,18 @@ func NewExportCmd() *cobra.Command {
 
 			config := gh.ExportRuleset(ruleset)
 
-			var jsonData []byte
-			jsonData, err = json.MarshalIndent(config, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal ruleset to JSON: %w", err)
-			}
-
 			if output == "" || output == "-" {
 				// Output to stdout
-				fmt.Println(string(jsonData))
+				data, err := rulekit.MarshalConfig(config, rulekit.ResolveFormat(format, ""))
+				if err != nil {
+					return fmt.Errorf("failed to marshal ruleset: %w", err)
+				}
+				fmt.Print(string(data))
 			} else {
 				// Output to file
-				err = os.WriteFile(output, jsonData, 0644)
+				err = rulekit.WriteConfigFile(output, config, rulekit.ResolveFormat(format, output))
 				if err != nil {
-					return fmt.Errorf("failed to write JSON to file: %w", err)
+					return fmt.Errorf("failed to write ruleset to file: %w", err)
 				}
 				logger.Info("Export completed successfully.", "output", output)
 			}
@@ -73,7 +88,14 @@ func NewExportCmd() *cobra.Command {
 	f := cmd.Flags()
 	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
 	f.StringVarP(&output, "output", "o", "", "Output file path (default: stdout)")
-	f.BoolVarP(&includesParent, "includes-parent", "p", false, "Include parent rulesets")
+	cmdutil.StringEnumFlag(cmd, &format, "format", "", "", rulekit.Formats, "Output file format (default: by output file extension, otherwise json)")
+	f.BoolVarP(&includesParent, "includes-parent", "p", false, "Include parent rulesets, not with --all")
+	f.BoolVar(&all, "all", false, "Export every ruleset to the directory specified by --dir")
+	f.StringVarP(&dir, "dir", "d", "", "Output directory for --all")
+	cmd.MarkFlagsRequiredTogether("all", "dir")
+	cmd.MarkFlagsMutuallyExclusive("dir", "output")
+	// Inherited rulesets belong to the organization or enterprise, not to the repository directory
+	cmd.MarkFlagsMutuallyExclusive("all", "includes-parent")
 
 	return cmd
 }
@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/cmd/repo/history"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+type HistoryOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewHistoryCmd returns a new cobra.Command for the version history of a repository ruleset
+func NewHistoryCmd() *cobra.Command {
+	var opts HistoryOptions
+	var repo string
+
+	cmd := &cobra.Command{
+		Use:   "history <ruleset>",
+		Short: "Show the version history of a repository ruleset",
+		Long:  `List the versions of a repository ruleset, specified by ID or name, newest first, with the actor who made each version and when. Use the show subcommand to print a past version in the export format and the diff subcommand to compare two versions. If repo is not specified, the current repository will be used.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			ruleset, err := rulekit.FindRuleset(ctx, client, repository, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find repository ruleset: %w", err)
+			}
+
+			versions, err := rulekit.ListRulesetHistory(ctx, client, repository, ruleset.GetID())
+			if err != nil {
+				return fmt.Errorf("failed to list ruleset history: %w", err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.RenderRulesetHistory(versions)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(history.NewDiffCmd())
+	cmd.AddCommand(history.NewShowCmd())
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
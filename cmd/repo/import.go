@@ -3,10 +3,11 @@ package repo
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/logger"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
@@ -23,11 +24,15 @@ func NewImportCmd() *cobra.Command {
 	var repo string
 	var input string
 	var createIfNotExists bool
+	var plan bool
+	var colorFlag string
+	var templateVars []string
+	var varsFile string
 
 	cmd := &cobra.Command{
 		Use:   "import <input>",
-		Short: "Import a repository ruleset from JSON file",
-		Long:  `Import a repository ruleset from a JSON file. If repo is not specified, the current repository will be used. Use --update flag with --ruleset-id to update an existing ruleset.`,
+		Short: "Import repository rulesets from JSON or YAML files",
+		Long:  `Import repository rulesets from a JSON or YAML file ('-' for stdin), a directory or a glob pattern. A file may hold a single ruleset, a JSON array of rulesets or a multi-document YAML. The format is chosen by the file extension and detected from the content otherwise. Each ruleset is matched by ID and then by name, and the result of each one is reported; files that cannot be read or parsed are reported as failures without stopping the others. The command fails if any of them fails. If repo is not specified, the current repository will be used. Use --create-if-none flag to create a new ruleset if it does not exist. Use --plan flag to show a field-level diff of the changes without writing them. Ruleset files may be Go templates filled with --var and --vars-file, see Ruleset Templates in the README.`,
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			input = args[0]
@@ -37,18 +42,9 @@ func NewImportCmd() *cobra.Command {
 				return fmt.Errorf("error parsing repository: %w", err)
 			}
 
-			var config *gh.RepositoryRulesetConfig
-			if input == "-" {
-				// Read from stdin
-				config, err = gh.LoadRepositoryRulesetConfigFromReader(os.Stdin)
-				if err != nil {
-					return fmt.Errorf("failed to read from stdin: %w", err)
-				}
-			} else {
-				config, err = gh.LoadRepositoryRulesetConfig(input)
-				if err != nil {
-					return fmt.Errorf("failed to read JSON file: %w", err)
-				}
+			vars, err := rulekit.LoadTemplateVars(varsFile, templateVars)
+			if err != nil {
+				return fmt.Errorf("failed to read template variables: %w", err)
 			}
 
 			ctx := context.Background()
@@ -56,36 +52,71 @@ func NewImportCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
-			found, err := gh.FindRepositoryRuleset(ctx, client, repository, *config.ID, config.Name, false)
+
+			td := rulekit.NewTemplateData(ctx, client, repository, vars)
+			files, err := rulekit.LoadConfigInput(input, td)
 			if err != nil {
-				return fmt.Errorf("failed to find repository ruleset: %w", err)
-			}
-			if found == nil && !createIfNotExists {
-				return fmt.Errorf("ruleset not found with ID %d or name '%s'", *config.ID, config.Name)
+				return fmt.Errorf("failed to read ruleset files: %w", err)
 			}
 
-			// Convert to RepositoryRuleset
-			ruleset := gh.ImportRuleset(config, found)
+			if plan {
+				var plans []*rulekit.Plan
+				for _, file := range files {
+					if file.Err != nil {
+						logger.Error("Failed to read ruleset file", "path", file.Path, "error", file.Err)
+						continue
+					}
+					p, err := rulekit.PlanImport(ctx, client, repository, parser.GetRepositoryFullName(repository), file.Config, createIfNotExists)
+					if err != nil {
+						if len(files) == 1 {
+							return fmt.Errorf("failed to plan repository ruleset: %w", err)
+						}
+						logger.Error("Failed to plan ruleset", "name", file.Config.Name, "path", file.String(), "error", err)
+						continue
+					}
+					plans = append(plans, p)
+				}
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans(plans)
+				if len(plans) < len(files) {
+					return fmt.Errorf("failed to plan %d of %d rulesets", len(files)-len(plans), len(files))
+				}
+				return nil
+			}
 
-			resultRuleset := found // nolint
-			if found == nil && createIfNotExists {
-				// Create new ruleset
-				resultRuleset, err = gh.CreateRepositoryRuleset(ctx, client, repository, ruleset)
+			if len(files) == 1 {
+				resultRuleset, action, err := rulekit.ImportConfig(ctx, client, repository, files[0].Config, createIfNotExists)
 				if err != nil {
-					return fmt.Errorf("failed to create repository ruleset: %w", err)
+					return fmt.Errorf("failed to import repository ruleset: %w", err)
 				}
-				logger.Info("Successfully created ruleset.", "rulesetID", *resultRuleset.ID, "rulesetName", resultRuleset.Name, "repository", parser.GetRepositoryFullName(repository))
-			} else {
-				// Update existing ruleset
-				resultRuleset, err = gh.UpdateRepositoryRuleset(ctx, client, repository, *found.ID, ruleset)
+				logger.Info("Successfully imported ruleset.", "action", action, "rulesetID", *resultRuleset.ID, "rulesetName", resultRuleset.Name, "repository", parser.GetRepositoryFullName(repository))
+
+				renderer := render.NewRenderer(opts.Exporter)
+				renderer.RenderRepositoryRuleset(resultRuleset, true)
+				return nil
+			}
+
+			logger.Info("Starting import", "repository", parser.GetRepositoryFullName(repository), "count", len(files))
+			successCount := 0
+			for _, file := range files {
+				if file.Err != nil {
+					logger.Error("Failed to read ruleset file", "path", file.Path, "error", file.Err)
+					continue
+				}
+				resultRuleset, action, err := rulekit.ImportConfig(ctx, client, repository, file.Config, createIfNotExists)
 				if err != nil {
-					return fmt.Errorf("failed to update repository ruleset: %w", err)
+					logger.Error("Failed to import ruleset", "name", file.Config.Name, "path", file.String(), "error", err)
+					continue
 				}
-				logger.Info("Successfully updated ruleset.", "rulesetID", *resultRuleset.ID, "rulesetName", resultRuleset.Name, "repository", parser.GetRepositoryFullName(repository))
+				logger.Info("Successfully imported ruleset.", "action", action, "rulesetID", *resultRuleset.ID, "rulesetName", resultRuleset.Name, "path", file.String())
+				successCount++
 			}
 
-			renderer := render.NewRenderer(opts.Exporter)
-			renderer.RenderRepositoryRuleset(resultRuleset, true)
+			logger.Info("Import completed", "total", len(files), "success", successCount, "failed", len(files)-successCount)
+			if successCount < len(files) {
+				return fmt.Errorf("failed to import %d of %d rulesets", len(files)-successCount, len(files))
+			}
 			return nil
 		},
 	}
@@ -93,6 +124,10 @@ func NewImportCmd() *cobra.Command {
 	f := cmd.Flags()
 	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
 	f.BoolVarP(&createIfNotExists, "create-if-none", "c", false, "Create a new ruleset if it does not exist")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	f.StringArrayVar(&templateVars, "var", nil, "Set a template variable in the format 'key=value' (can be specified multiple times)")
+	f.StringVar(&varsFile, "vars-file", "", "Read template variables from a JSON or YAML file")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
 	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
 
 	return cmd
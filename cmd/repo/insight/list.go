@@ -6,6 +6,7 @@ import (
 
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
 	"github.com/srz-zumix/go-gh-extension/pkg/render"
@@ -21,6 +22,8 @@ func NewListCmd() *cobra.Command {
 	var repo string
 	var ref string
 	var timePeriod string
+	var from string
+	var to string
 	var actorName string
 	var ruleSuiteResult string
 
@@ -49,9 +52,22 @@ func NewListCmd() *cobra.Command {
 				RuleSuiteResult: ruleSuiteResult,
 			}
 
-			ruleSuites, err := gh.ListRepositoryRuleSuites(ctx, ghClient, repository, listOpts)
-			if err != nil {
-				return fmt.Errorf("failed to list repository rule suites: %w", err)
+			archived := from != "" || to != ""
+			var ruleSuites []*gh.RuleSuite
+			if archived {
+				query, err := rulekit.NewRuleSuiteQuery(from, to, listOpts)
+				if err != nil {
+					return err
+				}
+				ruleSuites, err = rulekit.QueryRuleSuiteArchive(ghClient, repository, query)
+				if err != nil {
+					return fmt.Errorf("failed to query archived repository rule suites: %w", err)
+				}
+			} else {
+				ruleSuites, err = gh.ListRepositoryRuleSuites(ctx, ghClient, repository, listOpts)
+				if err != nil {
+					return fmt.Errorf("failed to list repository rule suites: %w", err)
+				}
 			}
 
 			renderer := render.NewRenderer(opts.Exporter)
@@ -64,9 +80,13 @@ func NewListCmd() *cobra.Command {
 	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
 	f.StringVar(&ref, "ref", "", "Filter by ref name (e.g., 'main', 'refs/heads/main')")
 	f.StringVar(&timePeriod, "time-period", "", "Filter by time period (e.g., 'hour', 'day', 'week', 'month')")
+	f.StringVar(&from, "from", "", "Query the local archive for rule suites pushed on or after the date (YYYY-MM-DD or RFC 3339)")
+	f.StringVar(&to, "to", "", "Query the local archive for rule suites pushed on or before the date (YYYY-MM-DD or RFC 3339)")
 	f.StringVar(&actorName, "actor-name", "", "Filter by actor name")
 	f.StringVar(&ruleSuiteResult, "result", "", "Filter by rule suite result (e.g., 'pass', 'fail', 'bypass')")
 	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+	cmd.MarkFlagsMutuallyExclusive("time-period", "from")
+	cmd.MarkFlagsMutuallyExclusive("time-period", "to")
 
 	return cmd
 }
@@ -0,0 +1,108 @@
+package insight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+type StatsOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewStatsCmd returns a new cobra.Command for aggregating repository rule suites
+func NewStatsCmd() *cobra.Command {
+	var opts StatsOptions
+	var repo string
+	var ref string
+	var timePeriod string
+	var from string
+	var to string
+	var actorName string
+	var ruleSuiteResult string
+	var by []string
+	var top int
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show repository rule suite statistics",
+		Long:  `Show how often rule suites of a repository pass, fail and are bypassed over the chosen period, per ruleset, rule type, actor and ref. If repo is not specified, the current repository will be used. Actor and ref count rule suites; ruleset and rule count rule evaluations, where a failed evaluation of a bypassed rule suite counts as a bypass. Rule evaluations are fetched for each rule suite, so use --limit flag to cap the number of rule suites on busy repositories, or --by flag to select dimensions that do not need them.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dimensions, err := rulekit.ParseInsightDimensions(by, rulekit.RepositoryInsightDimensions)
+			if err != nil {
+				return err
+			}
+
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			ghClient, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			listOpts := &gh.ListRuleSuitesOptions{
+				Ref:             ref,
+				TimePeriod:      timePeriod,
+				ActorName:       actorName,
+				RuleSuiteResult: ruleSuiteResult,
+			}
+
+			archived := from != "" || to != ""
+			var ruleSuites []*gh.RuleSuite
+			if archived {
+				query, err := rulekit.NewRuleSuiteQuery(from, to, listOpts)
+				if err != nil {
+					return err
+				}
+				ruleSuites, err = rulekit.QueryRuleSuiteArchive(ghClient, repository, query)
+				if err != nil {
+					return fmt.Errorf("failed to query archived repository rule suites: %w", err)
+				}
+			} else {
+				ruleSuites, err = gh.ListRepositoryRuleSuites(ctx, ghClient, repository, listOpts)
+				if err != nil {
+					return fmt.Errorf("failed to list repository rule suites: %w", err)
+				}
+			}
+			if limit > 0 && len(ruleSuites) > limit {
+				ruleSuites = ruleSuites[:limit]
+			}
+			if !archived && rulekit.NeedsRuleEvaluations(dimensions) {
+				ruleSuites = rulekit.GetRuleSuiteDetails(ctx, ghClient, repository, ruleSuites)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.RenderInsightStats(rulekit.AggregateRuleSuites(ruleSuites, dimensions, top))
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	f.StringVar(&ref, "ref", "", "Filter by ref name (e.g., 'main', 'refs/heads/main')")
+	f.StringVar(&timePeriod, "time-period", "", "Filter by time period (e.g., 'hour', 'day', 'week', 'month')")
+	f.StringVar(&from, "from", "", "Query the local archive for rule suites pushed on or after the date (YYYY-MM-DD or RFC 3339)")
+	f.StringVar(&to, "to", "", "Query the local archive for rule suites pushed on or before the date (YYYY-MM-DD or RFC 3339)")
+	f.StringVar(&actorName, "actor-name", "", "Filter by actor name")
+	f.StringVar(&ruleSuiteResult, "result", "", "Filter by rule suite result (e.g., 'pass', 'fail', 'bypass')")
+	f.StringSliceVar(&by, "by", nil, fmt.Sprintf("Dimensions to group by: %v (default: ruleset, rule, actor and ref)", rulekit.InsightDimensions))
+	f.IntVar(&limit, "limit", 0, "Maximum number of most recent rule suites to aggregate (0 for all)")
+	f.IntVar(&top, "top", 0, "Show only the given number of keys with the most failures and bypasses per dimension (0 for all)")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+	cmd.MarkFlagsMutuallyExclusive("time-period", "from")
+	cmd.MarkFlagsMutuallyExclusive("time-period", "to")
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package insight
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+// NewWatchCmd returns a new cobra.Command for watching repository rule suites
+func NewWatchCmd() *cobra.Command {
+	var repo string
+	var ref string
+	var actorName string
+	var ruleSuiteResult string
+	var interval time.Duration
+	var jsonLines bool
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch repository rule suites as they arrive",
+		Long:  `Poll the rule suites of a repository and print each new one as it arrives, along with its rule evaluations that did not pass, until interrupted. If repo is not specified, the current repository will be used. Rule suites that exist when watching starts are not printed. Evaluations of rulesets in evaluate mode are included, so this shows what a ruleset would have blocked. Use --json-lines flag to print each rule suite as a line of JSON.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("invalid interval '%s'", interval)
+			}
+
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			ghClient, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			listOpts := gh.ListRuleSuitesOptions{
+				Ref:             ref,
+				ActorName:       actorName,
+				RuleSuiteResult: ruleSuiteResult,
+			}
+
+			renderer := report.NewRenderer(nil)
+			watcher := rulekit.NewRuleSuiteWatcher(ghClient, repository, listOpts)
+			err = watcher.Watch(ctx, interval, func(suite *gh.RuleSuite) error {
+				return renderer.RenderRuleSuiteEvent(suite, jsonLines)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to watch repository rule suites: %w", err)
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	f.StringVar(&ref, "ref", "", "Filter by ref name (e.g., 'main', 'refs/heads/main')")
+	f.StringVar(&actorName, "actor-name", "", "Filter by actor name")
+	f.StringVar(&ruleSuiteResult, "result", "", "Filter by rule suite result (e.g., 'pass', 'fail', 'bypass')")
+	f.DurationVar(&interval, "interval", 30*time.Second, "Interval between polls")
+	f.BoolVar(&jsonLines, "json-lines", false, "Print each rule suite as a line of JSON")
+
+	return cmd
+}
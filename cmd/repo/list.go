@@ -6,9 +6,9 @@ import (
 
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
-	"github.com/srz-zumix/go-gh-extension/pkg/render"
 )
 
 type ListOptions struct {
@@ -24,7 +24,7 @@ func NewListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "list",
 		Short:   "List repository rulesets",
-		Long:    `List all rulesets for a repository. If repo is not specified, the current repository will be used.`,
+		Long:    `List all rulesets for a repository. Use --includes-parent to also list the organization and enterprise rulesets that apply to the repository, with the source they are defined in. If repo is not specified, the current repository will be used.`,
 		Aliases: []string{"ls"},
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -44,8 +44,12 @@ func NewListCmd() *cobra.Command {
 				return fmt.Errorf("failed to list repository rulesets: %w", err)
 			}
 
-			renderer := render.NewRenderer(opts.Exporter)
-			renderer.RenderRepositoryRulesetsDefault(rulesets)
+			renderer := report.NewRenderer(opts.Exporter)
+			if listIncludesParent {
+				renderer.RenderRulesetsWithSource(rulesets)
+			} else {
+				renderer.RenderRepositoryRulesetsDefault(rulesets)
+			}
 			return nil
 		},
 	}
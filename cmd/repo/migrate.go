@@ -5,59 +5,131 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/go-gh/v2/pkg/repository"
 	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
 	"github.com/srz-zumix/go-gh-extension/pkg/gh"
 	"github.com/srz-zumix/go-gh-extension/pkg/logger"
 	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
 )
 
+type MigrateOptions struct {
+	Exporter cmdutil.Exporter
+}
+
 // NewMigrateCmd returns a new cobra.Command for migrating repository rulesets
 func NewMigrateCmd() *cobra.Command {
+	var opts MigrateOptions
 	var srcRepo string
 	var gitHubActionsAppID int64
+	var plan bool
+	var colorFlag string
+	var dstRepos []string
+	var dstFile string
+	var dstOwner string
+	var dstTopics []string
+	var dstProperties []string
+	var dstNamePattern string
+	var onConflict string
+	var actorMapFile string
+	var selectedIDs []int64
 
 	cmd := &cobra.Command{
-		Use:   "migrate <dst-repo> [ruleset-id...]",
-		Short: "Migrate repository rulesets to another repository",
-		Long:  `Migrate repository rulesets from source repository to destination repository. If ruleset IDs are not specified, all rulesets will be migrated. Source repository is specified with --repo flag, destination repository is specified as the first argument.`,
-		Args:  cobra.MinimumNArgs(1),
+		Use:   "migrate [<dst-repo> [ruleset-id...]]",
+		Short: "Migrate repository rulesets to other repositories",
+		Long:  `Migrate repository rulesets from source repository to one or more destination repositories. The destination repository is specified as the first argument, followed by the IDs of the rulesets to migrate; IDs can also be given with --ruleset, and if none is specified, all rulesets will be migrated. More destinations can be given with --dst, read from a list file with --dst-file ('-' for stdin, one repository per line) and selected from an organization with --dst-owner, --dst-topic, --dst-property and --dst-name; archived repositories and the source repository are skipped. Progress is logged for each destination and a summary table is printed at the end, counting rulesets skipped as existing or as not supported by the destination; the command fails if any destination could not receive any ruleset. Rulesets are matched with the destination by name, and --on-conflict decides what happens to an existing one: update it in place (default), skip it, replace it by deleting and recreating it, create the new one under a free name such as 'name (2)' with rename, or fail; skip and update make the migration safe to rerun after a partial failure. Use --actor-map to map bypass actors and the integrations of required status checks when IDs or names differ, for example between GitHub Enterprise Server and GitHub Enterprise Cloud. The file is JSON or YAML with an 'actors' list of entries with 'type' (Team, Integration, OrganizationAdmin, RepositoryRole, or DeployKey), 'source' and 'destination', each an ID or a name (team slug, app slug or repository role name); a destination of 'none' drops the actor. With --actor-map, actors without an entry are matched by team slug or role name, and the ruleset fails when an actor cannot be resolved instead of being dropped. Without --actor-map, teams are matched by slug and the ruleset also fails when a team is not found in the destination. Use --plan flag to show a field-level diff against the destination rulesets, matched by name, without writing them. The plan is computed from the rulesets as they would be imported, after mapping them with --actor-map. Source repository is specified with --repo flag.`,
+		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Parse destination repository
-			dstRepository, err := parser.Repository(parser.RepositoryInput(args[0]))
-			if err != nil {
-				return fmt.Errorf("error parsing destination repository: %w", err)
-			}
-
 			// Parse source repository
 			srcRepository, err := parser.Repository(parser.RepositoryInput(srcRepo))
 			if err != nil {
 				return fmt.Errorf("error parsing source repository: %w", err)
 			}
 
+			var dstNames []string
+			rulesetIDs := selectedIDs
+			if len(args) > 0 {
+				if _, err := strconv.ParseInt(args[0], 10, 64); err == nil {
+					return fmt.Errorf("'%s' is not a repository: the destination repository must come before the ruleset IDs, or be given with --dst", args[0])
+				}
+				dstNames = append(dstNames, args[0])
+				for _, idStr := range args[1:] {
+					id, err := strconv.ParseInt(idStr, 10, 64)
+					if err != nil {
+						return fmt.Errorf("invalid ruleset ID '%s': %w", idStr, err)
+					}
+					rulesetIDs = append(rulesetIDs, id)
+				}
+			}
+			dstNames = append(dstNames, dstRepos...)
+			if dstFile != "" {
+				names, err := rulekit.ReadRepositoryList(dstFile)
+				if err != nil {
+					return fmt.Errorf("failed to read destination list: %w", err)
+				}
+				dstNames = append(dstNames, names...)
+			}
+
+			var dstRepositories []repository.Repository
+			for _, name := range dstNames {
+				dstRepository, err := parser.Repository(parser.RepositoryInput(name))
+				if err != nil {
+					return fmt.Errorf("error parsing destination repository '%s': %w", name, err)
+				}
+				dstRepositories = append(dstRepositories, dstRepository)
+			}
+
 			ctx := context.Background()
 
-			// Create clients for source and destination
+			// Create client for source
 			srcClient, err := gh.NewGitHubClientWithRepo(srcRepository)
 			if err != nil {
 				return fmt.Errorf("failed to create GitHub client for source repository: %w", err)
 			}
 
-			dstClient, err := gh.NewGitHubClientWithRepo(dstRepository)
+			properties, err := rulekit.ParsePropertyFilters(dstProperties)
 			if err != nil {
-				return fmt.Errorf("failed to create GitHub client for destination repository: %w", err)
+				return fmt.Errorf("error parsing destination property filter: %w", err)
 			}
-
-			var rulesetIDs []int64
-			if len(args) > 1 {
-				// Parse specified ruleset IDs
-				for _, idStr := range args[1:] {
-					id, err := strconv.ParseInt(idStr, 10, 64)
+			query := &rulekit.RepositoryQuery{Topics: dstTopics, Properties: properties, NamePattern: dstNamePattern}
+			if dstOwner != "" || !query.IsEmpty() {
+				owner := srcRepository
+				if dstOwner != "" {
+					owner, err = parser.Repository(parser.RepositoryOwnerWithHost(dstOwner))
 					if err != nil {
-						return fmt.Errorf("invalid ruleset ID '%s': %w", idStr, err)
+						return fmt.Errorf("error parsing destination owner: %w", err)
 					}
-					rulesetIDs = append(rulesetIDs, id)
 				}
-			} else {
+				ownerClient, err := gh.NewGitHubClientWithRepo(owner)
+				if err != nil {
+					return fmt.Errorf("failed to create GitHub client for destination owner: %w", err)
+				}
+				found, err := rulekit.QueryRepositories(ctx, ownerClient, owner, query)
+				if err != nil {
+					return fmt.Errorf("failed to query destination repositories: %w", err)
+				}
+				dstRepositories = append(dstRepositories, found...)
+			}
+
+			// Drop duplicates and the source repository itself
+			seen := map[string]bool{parser.GetRepositoryFullNameWithHost(srcRepository): true}
+			destinations := make([]repository.Repository, 0, len(dstRepositories))
+			for _, dstRepository := range dstRepositories {
+				key := parser.GetRepositoryFullNameWithHost(dstRepository)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				destinations = append(destinations, dstRepository)
+			}
+			if len(destinations) == 0 {
+				return fmt.Errorf("no destination repositories specified")
+			}
+
+			if len(rulesetIDs) == 0 {
 				// Get all rulesets from source repository
 				rulesets, err := gh.ListRepositoryRulesets(ctx, srcClient, srcRepository, false)
 				if err != nil {
@@ -75,41 +147,155 @@ func NewMigrateCmd() *cobra.Command {
 				return nil
 			}
 
-			logger.Info("Starting migration", "source", fmt.Sprintf("%s/%s", srcRepository.Owner, srcRepository.Name), "destination", fmt.Sprintf("%s/%s", dstRepository.Owner, dstRepository.Name), "count", len(rulesetIDs))
-
-			var gitHubActionsAppIDPtr *int64
-			if gitHubActionsAppID != 0 {
-				gitHubActionsAppIDPtr = &gitHubActionsAppID
+			var actorMap *rulekit.ActorMap
+			if actorMapFile != "" {
+				actorMap, err = rulekit.LoadActorMap(actorMapFile)
+				if err != nil {
+					return fmt.Errorf("failed to load actor map: %w", err)
+				}
 			}
 
-			// Migrate each ruleset
-			successCount := 0
+			// Export rulesets from source once (includes team information for actor mapping)
+			var migrateConfigs []*gh.RepositoryRulesetMigrateConfig
 			for _, rulesetID := range rulesetIDs {
-				logger.Info("Migrating ruleset", "id", rulesetID)
-
-				// Export ruleset from source (includes team information for actor mapping)
 				migrateConfig, err := gh.ExportMigrateRuleset(ctx, srcClient, srcRepository, rulesetID)
 				if err != nil {
 					logger.Error("Failed to export ruleset", "id", rulesetID, "error", err)
 					continue
 				}
+				migrateConfigs = append(migrateConfigs, migrateConfig)
+			}
 
-				// Import ruleset to destination (handles team actor ID mapping)
-				createdRuleset, err := gh.ImportMigrateRuleset(ctx, dstClient, dstRepository, migrateConfig, gitHubActionsAppIDPtr)
+			var gitHubActionsAppIDPtr *int64
+			if gitHubActionsAppID != 0 {
+				gitHubActionsAppIDPtr = &gitHubActionsAppID
+			}
+
+			var plans []*rulekit.Plan
+			planFailures := 0
+			results := make([]*rulekit.MigrationResult, 0, len(destinations))
+			for i, dstRepository := range destinations {
+				dstName := parser.GetRepositoryFullName(dstRepository)
+				logger.Info("Starting migration", "source", parser.GetRepositoryFullName(srcRepository), "destination", dstName, "count", len(rulesetIDs), "progress", fmt.Sprintf("%d/%d", i+1, len(destinations)))
+
+				dstClient, err := gh.NewGitHubClientWithRepo(dstRepository)
 				if err != nil {
-					logger.Error("Failed to import ruleset", "name", migrateConfig.Ruleset.Name, "error", err)
+					logger.Error("Failed to create GitHub client for destination repository", "destination", dstName, "error", err)
+					results = append(results, rulekit.NewMigrationResult(dstName, len(rulesetIDs), 0, err))
+					planFailures += len(rulesetIDs)
 					continue
 				}
 
-				logger.Info("Successfully migrated ruleset", "src_id", rulesetID, "dst_id", *createdRuleset.ID, "name", createdRuleset.Name)
-				successCount++
+				var resolver *rulekit.ActorResolver
+				if actorMap != nil {
+					resolver = rulekit.NewActorResolver(actorMap, srcClient, srcRepository, dstClient, dstRepository)
+				}
+
+				// Migrate each ruleset
+				successCount := 0
+				skipCount := 0
+				unsupportedCount := 0
+				for _, source := range migrateConfigs {
+					logger.Info("Migrating ruleset", "id", source.Ruleset.GetID(), "destination", dstName)
+
+					// Importing rewrites the config for the destination, so each destination gets its own copy
+					migrateConfig, err := rulekit.CopyMigrateConfig(source)
+					if err != nil {
+						logger.Error("Failed to copy ruleset", "name", source.Ruleset.Name, "error", err)
+						continue
+					}
+					var integrations rulekit.IntegrationMap
+					if resolver != nil {
+						if integrations, err = resolver.MapMigrateConfig(ctx, migrateConfig); err != nil {
+							logger.Error("Failed to map ruleset actors", "name", source.Ruleset.Name, "destination", dstName, "error", err)
+							continue
+						}
+					}
+
+					if plan {
+						found, err := gh.FindRulesetByName(ctx, dstClient, dstRepository, source.Ruleset.Name, false)
+						if err != nil {
+							logger.Error("Failed to find destination ruleset", "name", source.Ruleset.Name, "error", err)
+							continue
+						}
+						found, ok, err := rulekit.ResolvePlanConflict(rulekit.ConflictStrategy(onConflict), found)
+						if err != nil {
+							logger.Error("Failed to plan ruleset", "name", source.Ruleset.Name, "error", err)
+							continue
+						}
+						if !ok {
+							logger.Info("Skipped existing ruleset", "name", source.Ruleset.Name)
+							successCount++
+							continue
+						}
+						current, err := rulekit.ExportCurrentRuleset(ctx, dstClient, dstRepository, found)
+						if err != nil {
+							logger.Error("Failed to get destination ruleset", "name", source.Ruleset.Name, "error", err)
+							continue
+						}
+						p, err := rulekit.NewPlan(dstName, current, gh.ExportRuleset(migrateConfig.Ruleset))
+						if err != nil {
+							logger.Error("Failed to compute ruleset plan", "name", source.Ruleset.Name, "error", err)
+							continue
+						}
+						plans = append(plans, p)
+						successCount++
+						continue
+					}
+
+					// Import ruleset to destination (handles team actor ID mapping)
+					createdRuleset, action, err := rulekit.ImportMigrateRuleset(ctx, dstClient, dstRepository, migrateConfig, rulekit.ConflictStrategy(onConflict), gitHubActionsAppIDPtr, integrations)
+					if err != nil {
+						logger.Error("Failed to import ruleset", "name", source.Ruleset.Name, "destination", dstName, "error", err)
+						continue
+					}
+					switch action {
+					case rulekit.MigrationActionUnsupported:
+						logger.Warn("Skipped ruleset not supported by the destination", "name", source.Ruleset.Name, "destination", dstName)
+						unsupportedCount++
+						successCount++
+						continue
+					case rulekit.MigrationActionSkipped:
+						logger.Info("Skipped existing ruleset", "name", source.Ruleset.Name, "destination", dstName)
+						skipCount++
+						successCount++
+						continue
+					}
+
+					logger.Info("Successfully migrated ruleset", "src_id", source.Ruleset.GetID(), "dst_id", *createdRuleset.ID, "name", createdRuleset.Name, "action", action, "destination", dstName)
+					successCount++
+				}
+
+				planFailures += len(rulesetIDs) - successCount
+				result := rulekit.NewMigrationResult(dstName, len(rulesetIDs), successCount, nil)
+				result.Skipped = skipCount
+				result.Unsupported = unsupportedCount
+				results = append(results, result)
 			}
 
-			logger.Info("Migration completed", "total", len(rulesetIDs), "success", successCount, "failed", len(rulesetIDs)-successCount)
+			if plan {
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans(plans)
+				if planFailures > 0 {
+					return fmt.Errorf("failed to plan %d rulesets", planFailures)
+				}
+				return nil
+			}
+
+			failed := rulekit.CountFailedMigrations(results)
+			logger.Info("Migration completed", "destinations", len(results), "failed", failed)
 
-			if successCount == 0 {
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderMigrationResults(results)
+
+			if len(results) == 1 && failed == 1 {
 				return fmt.Errorf("failed to migrate any rulesets")
 			}
+			if failed > 0 {
+				return fmt.Errorf("failed to migrate rulesets to %d of %d destinations", failed, len(results))
+			}
 
 			return nil
 		},
@@ -117,7 +303,19 @@ func NewMigrateCmd() *cobra.Command {
 
 	f := cmd.Flags()
 	f.StringVarP(&srcRepo, "repo", "R", "", "The source repository in the format 'owner/repo'")
+	f.Int64SliceVar(&selectedIDs, "ruleset", nil, "ID of a ruleset to migrate, all rulesets when not specified (can be specified multiple times)")
+	f.StringVar(&actorMapFile, "actor-map", "", "Map bypass actors and status check integrations with a JSON or YAML file, failing on actors that cannot be resolved")
 	f.Int64Var(&gitHubActionsAppID, "github-actions-app-id", 0, "The GitHub Actions App ID for integration mapping")
+	f.StringArrayVar(&dstRepos, "dst", nil, "Destination repository in the format '[HOST/]OWNER/REPO' (can be specified multiple times)")
+	f.StringVar(&dstFile, "dst-file", "", "Read destination repositories from a file, one per line ('-' for stdin)")
+	f.StringVar(&dstOwner, "dst-owner", "", "Select destination repositories from an organization in the format '[HOST/]OWNER'")
+	f.StringArrayVar(&dstTopics, "dst-topic", nil, "Select destination repositories that have a topic (can be specified multiple times)")
+	f.StringArrayVar(&dstProperties, "dst-property", nil, "Select destination repositories whose custom property has a value, in the format 'name=value' (can be specified multiple times)")
+	f.StringVar(&dstNamePattern, "dst-name", "", "Select destination repositories whose name matches a glob pattern")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &onConflict, "on-conflict", "", string(rulekit.ConflictUpdate), rulekit.ConflictStrategies, "What to do when a ruleset with the same name exists in the destination")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan and summary output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
 
 	return cmd
 }
@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/cmd/repo/rule"
+)
+
+// NewRuleCmd returns a new cobra.Command for editing the rules of a repository ruleset
+func NewRuleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rule",
+		Short: "Edit the rules of a repository ruleset",
+		Long:  `Commands to add, remove and change the rules of a repository ruleset in place`,
+	}
+
+	cmd.AddCommand(rule.NewAddCmd())
+	cmd.AddCommand(rule.NewRemoveCmd())
+	cmd.AddCommand(rule.NewSetParamCmd())
+
+	return cmd
+}
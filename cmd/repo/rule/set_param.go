@@ -0,0 +1,80 @@
+package rule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type SetParamOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewSetParamCmd returns a new cobra.Command for changing rule parameters of a repository ruleset
+func NewSetParamCmd() *cobra.Command {
+	var opts SetParamOptions
+	var repo string
+	var params []string
+	var plan bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "set-param <ruleset> <type>",
+		Short: "Change parameters of a rule in a repository ruleset",
+		Long:  `Change parameters of the rule of the given type in a repository ruleset, specified by ID or name, without exporting and importing the whole ruleset. Parameters are given with --param as 'name=value' to set a value, 'name+=value' to add a value to a list parameter, or 'name-=value' to remove a value from it, where the value is parsed as JSON when possible (e.g., 'required_approving_review_count=2', 'required_status_checks+={"context":"ci"}'). The ruleset is validated after the change and backed up before it is updated. Use --plan flag to show a field-level diff of the change without writing it. If repo is not specified, the current repository will be used.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			repository, err := parser.Repository(parser.RepositoryInput(repo))
+			if err != nil {
+				return fmt.Errorf("error parsing repository: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(repository)
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			p, err := rulekit.PlanRulesetEdit(ctx, client, repository, parser.GetRepositoryFullName(repository), args[0], rulekit.SetRuleParams(args[1], params))
+			if err != nil {
+				return fmt.Errorf("failed to change rule parameters: %w", err)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderPlans([]*rulekit.Plan{p})
+			if plan || p.Action == rulekit.ApplyActionNoChange {
+				return nil
+			}
+			if guardrails.IsReadonly() {
+				return fmt.Errorf("cannot edit ruleset in read-only mode")
+			}
+
+			updated, err := rulekit.ApplyRulesetEdit(ctx, client, repository, p)
+			if err != nil {
+				return fmt.Errorf("failed to update repository ruleset: %w", err)
+			}
+			logger.Info("Successfully changed rule parameters.", "rulesetID", updated.GetID(), "rulesetName", updated.Name, "type", args[1])
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&repo, "repo", "R", "", "The repository in the format 'owner/repo'")
+	f.StringArrayVar(&params, "param", nil, "Rule parameter change as 'name=value', 'name+=value' or 'name-=value' (can be repeated)")
+	_ = cmd.MarkFlagRequired("param")
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type RestoreOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewRestoreCmd returns a new cobra.Command for restoring a ruleset from a backup snapshot
+func NewRestoreCmd() *cobra.Command {
+	var opts RestoreOptions
+	var plan bool
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "restore <snapshot>",
+		Short: "Restore a ruleset from a backup snapshot",
+		Long:  `Restore a ruleset from a backup snapshot to the repository, organization or enterprise it was taken from. Before delete, import, apply and migrate overwrite or remove a ruleset, the current version is saved as a snapshot under $GH_RULE_KIT_BACKUP_DIR, or gh-rule-kit/backups under $XDG_STATE_HOME (default: ~/.local/state), in HOST/OWNER[/REPO]/TIMESTAMP-OPERATION-NAME.json. The ruleset is matched by ID and then by name and updated, or created again when it was deleted; the version being overwritten is backed up as well. Use --plan flag to show a field-level diff of the changes without writing them.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshot, err := rulekit.LoadSnapshot(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read snapshot: %w", err)
+			}
+
+			ctx := context.Background()
+			client, err := gh.NewGitHubClientWithRepo(snapshot.Repository())
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			if plan {
+				p, err := rulekit.PlanRestore(ctx, client, snapshot)
+				if err != nil {
+					return fmt.Errorf("failed to plan ruleset restore: %w", err)
+				}
+				renderer := report.NewRenderer(opts.Exporter)
+				renderer.SetColor(colorFlag)
+				renderer.RenderPlans([]*rulekit.Plan{p})
+				return nil
+			}
+
+			restored, action, err := rulekit.RestoreSnapshot(ctx, client, snapshot)
+			if err != nil {
+				return fmt.Errorf("failed to restore ruleset: %w", err)
+			}
+			logger.Info("Successfully restored ruleset.", "action", action, "rulesetID", restored.GetID(), "rulesetName", restored.Name, "target", snapshot.Target(), "snapshot", snapshot.CreatedAt)
+
+			renderer := render.NewRenderer(opts.Exporter)
+			renderer.RenderRepositoryRuleset(restored, true)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&plan, "plan", false, "Show the changes that would be made without writing them")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in plan output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewRestoreCmd())
+}
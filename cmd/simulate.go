@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type SimulateOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewSimulateCmd returns a new cobra.Command for simulating an event against rulesets
+func NewSimulateCmd() *cobra.Command {
+	var opts SimulateOptions
+	var event rulekit.SimulationEvent
+	var action string
+	var files []string
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "simulate <ruleset>...",
+		Short: "Simulate whether a push would be blocked by rulesets",
+		Long:  `Simulate a hypothetical push against one or more rulesets and report which rules would block it. Each ruleset is a local file ('-' for stdin), 'repo:[HOST/]OWNER/REPO#ID-OR-NAME' or 'org:[HOST/]OWNER#ID-OR-NAME'. Rulesets are matched with the event by target, ref name condition (including ~DEFAULT_BRANCH and ~ALL) and repository name condition like GitHub does, and push rulesets apply to every event. Branch and tag names given to --ref are qualified with refs/heads/ or refs/tags/ for the target. Commit metadata, ref name and file rules are evaluated against the given commit messages, emails and changed files, and pull request and merge queue rules block updates of the ref; rules that depend on status checks, signatures or deployments are reported as unchecked. ~DEFAULT_BRANCH matches --default-branch, or when it is not given, the default branch of the repository for 'repo:' rulesets and main for other rulesets. Only rulesets in active enforcement block the event. Exits with 0 when the event is allowed, 2 when it would be blocked and 1 on error.`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if event.Ref == "" && event.Target != "push" {
+				return fmt.Errorf("--ref is required for %s events", event.Target)
+			}
+			event.Ref = rulekit.QualifyRef(event.Ref, event.Target)
+			event.Action = rulekit.RefAction(action)
+			for _, spec := range files {
+				file, err := rulekit.ParseChangedFile(spec)
+				if err != nil {
+					return fmt.Errorf("error parsing changed file: %w", err)
+				}
+				event.Files = append(event.Files, file)
+			}
+
+			ctx := context.Background()
+			sims := make([]*rulekit.Simulation, 0, len(args))
+			for _, arg := range args {
+				source, err := rulekit.ParseSource(arg)
+				if err != nil {
+					return fmt.Errorf("error parsing ruleset source: %w", err)
+				}
+				config, err := source.Load(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to load ruleset from %s: %w", source, err)
+				}
+				sourceEvent := event
+				if sourceEvent.DefaultBranch == "" {
+					branch, err := source.DefaultBranch(ctx)
+					if err != nil {
+						return fmt.Errorf("failed to get default branch of %s: %w", source, err)
+					}
+					if branch == "" {
+						branch = rulekit.DefaultSimulationBranch
+					}
+					sourceEvent.DefaultBranch = branch
+				}
+				sims = append(sims, rulekit.Simulate(source.String(), config, &sourceEvent))
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderSimulations(sims)
+
+			if rulekit.IsBlocked(sims) {
+				cmd.SilenceUsage = true
+				return rulekit.NewExitCodeError(rulekit.ExitCodeBlocked, "the event would be blocked by rulesets")
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	cmdutil.StringEnumFlag(cmd, &action, "action", "", string(rulekit.RefActionUpdate), rulekit.RefActions, "What the push does to the ref")
+	f.StringArrayVar(&event.AuthorEmails, "author-email", nil, "Author email of a pushed commit (can be specified multiple times)")
+	f.StringArrayVarP(&event.CommitMessages, "commit-message", "m", nil, "Message of a pushed commit (can be specified multiple times)")
+	f.StringArrayVar(&event.CommitterEmails, "committer-email", nil, "Committer email of a pushed commit (can be specified multiple times)")
+	f.StringVar(&event.DefaultBranch, "default-branch", "", "Default branch of the repository, matched by ~DEFAULT_BRANCH (default: the default branch of repo: rulesets, main otherwise)")
+	f.StringArrayVar(&files, "file", nil, "Changed file in the format 'PATH[:SIZE]' with SIZE in bytes (can be specified multiple times)")
+	f.BoolVar(&event.Force, "force", false, "Simulate a force push")
+	f.StringVar(&event.Ref, "ref", "", "Branch, tag or fully qualified ref name that is pushed")
+	f.StringVar(&event.Repository, "repository", "", "Repository name, matched by repository name conditions")
+	cmdutil.StringEnumFlag(cmd, &event.Target, "target", "", "branch", rulekit.SimulationTargets, "Target of the event")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in simulation output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewSimulateCmd())
+}
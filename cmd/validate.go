@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/spf13/cobra"
+	"github.com/srz-zumix/gh-rule-kit/pkg/report"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/actions"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+type ValidateOptions struct {
+	Exporter cmdutil.Exporter
+}
+
+// NewValidateCmd returns a new cobra.Command for validating ruleset files
+func NewValidateCmd() *cobra.Command {
+	var opts ValidateOptions
+	var templateVars []string
+	var varsFile string
+	var colorFlag string
+
+	cmd := &cobra.Command{
+		Use:   "validate <input>...",
+		Short: "Validate ruleset files without contacting GitHub",
+		Long:  `Validate ruleset files (.json, .yaml or .yml) without contacting GitHub. Each input is a file ('-' for stdin), a directory or a glob pattern. Unknown fields and rule types, missing required rule parameters, invalid enforcement, target and other enumerated values, malformed fnmatch patterns in conditions and invalid regular expressions in metadata pattern rules are reported with the JSON path of the offending value. Ruleset files may be Go templates filled with --var and --vars-file, see Ruleset Templates in the README; repository metadata is not available. The command fails if any problem is found. When running on GitHub Actions, an error annotation is emitted for each problem.`,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vars, err := rulekit.LoadTemplateVars(varsFile, templateVars)
+			if err != nil {
+				return fmt.Errorf("failed to read template variables: %w", err)
+			}
+			td := rulekit.NewTemplateData(context.Background(), nil, repository.Repository{}, vars)
+
+			var files []string
+			errs := []*rulekit.ValidationError{}
+			for _, input := range args {
+				validated, found, err := rulekit.ValidateInput(input, td)
+				if err != nil {
+					return fmt.Errorf("failed to read ruleset files: %w", err)
+				}
+				files = append(files, validated...)
+				errs = append(errs, found...)
+			}
+
+			renderer := report.NewRenderer(opts.Exporter)
+			renderer.SetColor(colorFlag)
+			renderer.RenderValidationErrors(files, errs)
+			if actions.IsRunsOn() {
+				renderer.RenderValidationAnnotations(errs)
+			}
+
+			if len(errs) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("found %d problems in ruleset files", len(errs))
+			}
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVar(&templateVars, "var", nil, "Set a template variable in the format 'key=value' (can be specified multiple times)")
+	f.StringVar(&varsFile, "vars-file", "", "Read template variables from a JSON or YAML file")
+	cmdutil.StringEnumFlag(cmd, &colorFlag, "color", "", render.ColorFlagAuto, render.ColorFlags, "Use color in validation output")
+	cmdutil.AddFormatFlags(cmd, &opts.Exporter)
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewValidateCmd())
+}
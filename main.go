@@ -6,5 +6,5 @@ package main
 import "github.com/srz-zumix/gh-rule-kit/cmd"
 
 func main() {
-	cmd.Execute()
+	cmd.Run()
 }
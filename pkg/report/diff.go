@@ -0,0 +1,73 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+func formatChangeValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// FormatChange returns a single line describing a field-level change
+func FormatChange(change *rulekit.Change) string {
+	switch change.Type {
+	case rulekit.ChangeTypeAdded:
+		return fmt.Sprintf("+ %s: %s", change.Path, formatChangeValue(change.After))
+	case rulekit.ChangeTypeRemoved:
+		return fmt.Sprintf("- %s: %s", change.Path, formatChangeValue(change.Before))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", change.Path, formatChangeValue(change.Before), formatChangeValue(change.After))
+	}
+}
+
+func (r *Renderer) colorizeChange(change *rulekit.Change, line string) string {
+	if !r.Color {
+		return line
+	}
+	switch change.Type {
+	case rulekit.ChangeTypeAdded:
+		return color.GreenString(line)
+	case rulekit.ChangeTypeRemoved:
+		return color.RedString(line)
+	default:
+		return color.YellowString(line)
+	}
+}
+
+// RenderChanges renders field-level changes between two rulesets, or as JSON when an exporter is set
+func (r *Renderer) RenderChanges(changes []*rulekit.Change) {
+	if r.exporter != nil {
+		r.RenderExportedData(changes)
+		return
+	}
+
+	if len(changes) == 0 {
+		r.writeLine("No differences.")
+		return
+	}
+
+	for _, change := range changes {
+		r.writeLine(r.colorizeChange(change, FormatChange(change)))
+	}
+}
+
+// RenderUnifiedDiff renders a unified diff text
+func (r *Renderer) RenderUnifiedDiff(diff string) {
+	if diff == "" {
+		r.writeLine("No differences.")
+		return
+	}
+	if r.Color {
+		diff = colorizeUnifiedDiff(diff)
+	}
+	r.writeLine(strings.TrimSuffix(diff, "\n"))
+}
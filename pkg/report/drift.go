@@ -0,0 +1,99 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+func (r *Renderer) colorizeDriftStatus(status rulekit.DriftStatus) string {
+	if !r.Color {
+		return string(status)
+	}
+	switch status {
+	case rulekit.DriftStatusInSync:
+		return color.GreenString(string(status))
+	case rulekit.DriftStatusDrifted:
+		return color.RedString(string(status))
+	default:
+		return color.YellowString(string(status))
+	}
+}
+
+// RenderDrifts renders the drift report of rulesets, or as JSON when an exporter is set
+func (r *Renderer) RenderDrifts(drifts []*rulekit.Drift) {
+	if r.exporter != nil {
+		r.RenderExportedData(drifts)
+		return
+	}
+
+	if len(drifts) == 0 {
+		r.writeLine("No rulesets.")
+		return
+	}
+
+	table := r.newTableWriter([]string{"STATUS", "ID", "NAME", "PATH", "CHANGES"})
+	for _, drift := range drifts {
+		table.Append([]string{
+			r.colorizeDriftStatus(drift.Status),
+			render.ToString(drift.ID),
+			drift.Name,
+			drift.Path,
+			fmt.Sprintf("%d", len(drift.Changes)),
+		})
+	}
+	table.Render()
+
+	for _, drift := range drifts {
+		if len(drift.Changes) == 0 {
+			continue
+		}
+		r.writeLine("")
+		r.writeLine(fmt.Sprintf("Ruleset '%s' (%s) differs from the live ruleset (- file, + live):", drift.Name, drift.Path))
+		for _, change := range drift.Changes {
+			r.writeLine("  " + r.colorizeChange(change, FormatChange(change)))
+		}
+	}
+}
+
+func escapeAnnotationData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+func escapeAnnotationProperty(s string) string {
+	s = escapeAnnotationData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
+
+// RenderDriftAnnotations writes a GitHub Actions error annotation for every ruleset that is not in sync.
+// Annotations are written to stderr so that they do not mix with the report or JSON output.
+func (r *Renderer) RenderDriftAnnotations(drifts []*rulekit.Drift) {
+	for _, drift := range drifts {
+		var message string
+		switch drift.Status {
+		case rulekit.DriftStatusDrifted:
+			lines := []string{fmt.Sprintf("Ruleset '%s' has drifted from its file (- file, + live)", drift.Name)}
+			for _, change := range drift.Changes {
+				lines = append(lines, FormatChange(change))
+			}
+			message = strings.Join(lines, "\n")
+		case rulekit.DriftStatusMissing:
+			message = fmt.Sprintf("Ruleset '%s' does not exist on GitHub", drift.Name)
+		case rulekit.DriftStatusUnmanaged:
+			message = fmt.Sprintf("Ruleset '%s' exists on GitHub but has no file", drift.Name)
+		default:
+			continue
+		}
+		properties := []string{"title=" + escapeAnnotationProperty("Ruleset "+string(drift.Status))}
+		if drift.Path != "" {
+			properties = append([]string{"file=" + escapeAnnotationProperty(drift.Path)}, properties...)
+		}
+		fmt.Fprintf(r.IO.ErrOut, "::error %s::%s\n", strings.Join(properties, ","), escapeAnnotationData(message))
+	}
+}
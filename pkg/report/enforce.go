@@ -0,0 +1,33 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+// RenderEnforcementChanges renders the enforcement changes of rulesets with their previous values,
+// or as JSON when an exporter is set
+func (r *Renderer) RenderEnforcementChanges(changes []*rulekit.EnforcementChange) {
+	if r.exporter != nil {
+		r.RenderExportedData(changes)
+		return
+	}
+
+	if len(changes) == 0 {
+		r.writeLine("No rulesets.")
+		return
+	}
+
+	table := r.newTableWriter([]string{"ID", "NAME", "PREVIOUS", "ENFORCEMENT", "ACTION"})
+	for _, change := range changes {
+		table.Append([]string{
+			fmt.Sprintf("%d", change.RulesetID),
+			change.Name,
+			change.Previous,
+			change.Enforcement,
+			string(change.Action),
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,36 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+// RenderRulesetHistory renders the versions of a ruleset, or as JSON when an exporter is set
+func (r *Renderer) RenderRulesetHistory(versions []*rulekit.RulesetVersion) {
+	if r.exporter != nil {
+		r.RenderExportedData(versions)
+		return
+	}
+
+	if len(versions) == 0 {
+		r.writeLine("No versions.")
+		return
+	}
+
+	table := r.newTableWriter([]string{"VERSION", "ACTOR", "ACTOR_TYPE", "UPDATED_AT"})
+	for _, version := range versions {
+		actorType := ""
+		if version.Actor != nil {
+			actorType = version.Actor.Type
+		}
+		table.Append([]string{
+			fmt.Sprintf("%d", version.VersionID),
+			version.ActorName(),
+			actorType,
+			version.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	table.Render()
+}
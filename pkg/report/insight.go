@@ -0,0 +1,96 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+// RenderInsightStats renders rule suite statistics as a table per dimension, or as JSON when an exporter is set
+func (r *Renderer) RenderInsightStats(stats []*rulekit.InsightStat) {
+	if r.exporter != nil {
+		r.RenderExportedData(stats)
+		return
+	}
+
+	if len(stats) == 0 {
+		r.writeLine("No rule suites.")
+		return
+	}
+
+	r.renderInsightStatsByDimension(stats)
+}
+
+// RenderOrgRollup renders organization rule suite statistics per repository followed by the rules that cause the most
+// blocks and the repositories drilled down into, or as JSON when an exporter is set
+func (r *Renderer) RenderOrgRollup(rollup *rulekit.OrgRollup) {
+	if r.exporter != nil {
+		r.RenderExportedData(rollup)
+		return
+	}
+
+	if len(rollup.Repositories) == 0 {
+		r.writeLine("No rule suites.")
+		return
+	}
+
+	r.writeLine("Repositories:")
+	r.renderInsightStatTable(rollup.Repositories)
+	if len(rollup.Details) == 0 {
+		return
+	}
+
+	r.writeLine("")
+	r.writeLine("Rules causing the most blocks:")
+	if len(rollup.Rules) == 0 {
+		r.writeLine("No rule evaluations.")
+	} else {
+		r.renderInsightStatTable(rollup.Rules)
+	}
+	for _, detail := range rollup.Details {
+		r.writeLine("")
+		r.writeLine(fmt.Sprintf("Repository '%s':", detail.Repository))
+		if len(detail.Stats) == 0 {
+			r.writeLine("No rule evaluations.")
+			continue
+		}
+		r.renderInsightStatsByDimension(detail.Stats)
+	}
+}
+
+// renderInsightStatsByDimension renders a table per dimension of stats ordered by dimension
+func (r *Renderer) renderInsightStatsByDimension(stats []*rulekit.InsightStat) {
+	for i := 0; i < len(stats); {
+		dimension := stats[i].Dimension
+		j := i
+		for j < len(stats) && stats[j].Dimension == dimension {
+			j++
+		}
+		if i > 0 {
+			r.writeLine("")
+		}
+		r.writeLine(fmt.Sprintf("By %s:", dimension))
+		r.renderInsightStatTable(stats[i:j])
+		i = j
+	}
+}
+
+func (r *Renderer) renderInsightStatTable(stats []*rulekit.InsightStat) {
+	table := r.newTableWriter([]string{"KEY", "TOTAL", "PASS", "FAIL", "BYPASS", "FAIL_RATE", "BYPASS_RATE"})
+	for _, stat := range stats {
+		table.Append([]string{
+			stat.Key,
+			fmt.Sprintf("%d", stat.Total),
+			fmt.Sprintf("%d", stat.Pass),
+			fmt.Sprintf("%d", stat.Fail),
+			fmt.Sprintf("%d", stat.Bypass),
+			formatRate(stat.FailRate),
+			formatRate(stat.BypassRate),
+		})
+	}
+	table.Render()
+}
+
+func formatRate(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
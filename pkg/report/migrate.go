@@ -0,0 +1,48 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+func (r *Renderer) colorizeMigrationStatus(status rulekit.MigrationStatus) string {
+	if !r.Color {
+		return string(status)
+	}
+	switch status {
+	case rulekit.MigrationStatusSuccess:
+		return color.GreenString(string(status))
+	case rulekit.MigrationStatusFailed:
+		return color.RedString(string(status))
+	default:
+		return color.YellowString(string(status))
+	}
+}
+
+// RenderMigrationResults renders a summary of the migration to each destination, or as JSON when an exporter is set
+func (r *Renderer) RenderMigrationResults(results []*rulekit.MigrationResult) {
+	if r.exporter != nil {
+		r.RenderExportedData(results)
+		return
+	}
+
+	if len(results) == 0 {
+		r.writeLine("No destinations.")
+		return
+	}
+
+	table := r.newTableWriter([]string{"DESTINATION", "STATUS", "MIGRATED", "SKIPPED", "UNSUPPORTED", "ERROR"})
+	for _, result := range results {
+		table.Append([]string{
+			result.Destination,
+			r.colorizeMigrationStatus(result.Status),
+			fmt.Sprintf("%d/%d", result.Migrated, result.Total),
+			fmt.Sprintf("%d", result.Skipped),
+			fmt.Sprintf("%d", result.Unsupported),
+			result.Error,
+		})
+	}
+	table.Render()
+}
@@ -0,0 +1,74 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+func colorizeUnifiedDiff(diff string) string {
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = color.New(color.Bold).Sprint(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = color.CyanString(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = color.GreenString(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = color.RedString(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func planTitle(plan *rulekit.Plan) string {
+	title := fmt.Sprintf("# %s ruleset '%s'", plan.Action, plan.Name)
+	if plan.Target != "" {
+		title += " in " + plan.Target
+	}
+	return title
+}
+
+// RenderPlans renders the plans of a write operation as unified diffs, or as JSON when an exporter is set
+func (r *Renderer) RenderPlans(plans []*rulekit.Plan) {
+	if r.exporter != nil {
+		r.RenderExportedData(plans)
+		return
+	}
+
+	if len(plans) == 0 {
+		r.writeLine("No rulesets to change.")
+		return
+	}
+
+	counts := map[rulekit.ApplyAction]int{}
+	for _, plan := range plans {
+		counts[plan.Action]++
+		title := planTitle(plan)
+		if r.Color {
+			title = color.New(color.Bold).Sprint(title)
+		}
+		r.writeLine(title)
+		if plan.Action == rulekit.ApplyActionNoChange {
+			r.writeLine("No changes.")
+			r.writeLine("")
+			continue
+		}
+		diff, err := plan.UnifiedDiff()
+		if err != nil {
+			r.WriteError(err)
+			continue
+		}
+		r.RenderUnifiedDiff(diff)
+		r.writeLine("")
+	}
+	r.writeLine(fmt.Sprintf("Plan: %d to create, %d to update, %d to delete, %d unchanged.",
+		counts[rulekit.ApplyActionCreate],
+		counts[rulekit.ApplyActionUpdate],
+		counts[rulekit.ApplyActionDelete],
+		counts[rulekit.ApplyActionNoChange]))
+}
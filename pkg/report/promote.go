@@ -0,0 +1,39 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+// RenderPromotionImpact renders what a ruleset in evaluate mode would have blocked, or as JSON when an exporter is set
+func (r *Renderer) RenderPromotionImpact(impact *rulekit.PromotionImpact) {
+	if r.exporter != nil {
+		r.RenderExportedData(impact)
+		return
+	}
+
+	r.writeLine(fmt.Sprintf("Ruleset '%s' (%d) would have blocked %d of %d pushes.", impact.Ruleset, impact.RulesetID, impact.Blocked, impact.RuleSuites))
+	if impact.Truncated {
+		r.writeLine(fmt.Sprintf("Only %d of %d rule suites with failed evaluations were inspected, so more pushes may have been blocked.", impact.Inspected, impact.Failed))
+	}
+	if impact.Blocked == 0 {
+		return
+	}
+	r.renderImpactCounts("REPOSITORY", impact.Repositories)
+	r.renderImpactCounts("ACTOR", impact.Actors)
+	r.renderImpactCounts("REF", impact.Refs)
+	r.renderImpactCounts("RULE", impact.Rules)
+}
+
+func (r *Renderer) renderImpactCounts(header string, counts []*rulekit.ImpactCount) {
+	if len(counts) == 0 {
+		return
+	}
+	r.writeLine("")
+	table := r.newTableWriter([]string{header, "BLOCKED"})
+	for _, count := range counts {
+		table.Append([]string{count.Key, fmt.Sprintf("%d", count.Blocked)})
+	}
+	table.Render()
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/olekukonko/tablewriter"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+// Renderer renders gh-rule-kit specific output on top of the go-gh-extension renderer
+type Renderer struct {
+	*render.Renderer
+	exporter cmdutil.Exporter
+}
+
+// NewRenderer creates a new Renderer with the given exporter
+func NewRenderer(ex cmdutil.Exporter) *Renderer {
+	return &Renderer{
+		Renderer: render.NewRenderer(ex),
+		exporter: ex,
+	}
+}
+
+func (r *Renderer) writeLine(line string) {
+	_, err := fmt.Fprintln(r.IO.Out, line)
+	if err != nil {
+		r.WriteError(err)
+	}
+}
+
+func (r *Renderer) newTableWriter(header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(r.IO.Out)
+	table.SetHeader(header)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	return table
+}
@@ -0,0 +1,11 @@
+package report
+
+import (
+	"github.com/google/go-github/v79/github"
+)
+
+// RenderRulesetsWithSource renders rulesets with the type and name of the repository, organization or enterprise
+// they are defined in, so that inherited rulesets can be told apart
+func (r *Renderer) RenderRulesetsWithSource(rulesets []*github.RepositoryRuleset) {
+	r.RenderRepositoryRulesets(rulesets, []string{"ID", "NAME", "TARGET", "ENFORCEMENT", "SOURCE_TYPE", "SOURCE"})
+}
@@ -0,0 +1,85 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+func simulationResult(sim *rulekit.Simulation) string {
+	switch {
+	case !sim.Applies:
+		return "skipped"
+	case sim.Blocked:
+		return "blocked"
+	default:
+		return "allowed"
+	}
+}
+
+func (r *Renderer) colorizeSimulationResult(result string) string {
+	if !r.Color {
+		return result
+	}
+	switch result {
+	case "blocked":
+		return color.RedString(result)
+	case "allowed":
+		return color.GreenString(result)
+	default:
+		return color.YellowString(result)
+	}
+}
+
+func (r *Renderer) colorizeRuleOutcome(outcome rulekit.RuleOutcome) string {
+	if !r.Color {
+		return string(outcome)
+	}
+	switch outcome {
+	case rulekit.RuleOutcomeBlocked:
+		return color.RedString(string(outcome))
+	case rulekit.RuleOutcomePassed:
+		return color.GreenString(string(outcome))
+	default:
+		return color.YellowString(string(outcome))
+	}
+}
+
+// RenderSimulations renders the result of each ruleset against a simulated event, or as JSON when an exporter is set
+func (r *Renderer) RenderSimulations(sims []*rulekit.Simulation) {
+	if r.exporter != nil {
+		r.RenderExportedData(sims)
+		return
+	}
+
+	if len(sims) == 0 {
+		r.writeLine("No rulesets.")
+		return
+	}
+
+	table := r.newTableWriter([]string{"RESULT", "NAME", "ENFORCEMENT", "SOURCE", "REASON"})
+	for _, sim := range sims {
+		table.Append([]string{
+			r.colorizeSimulationResult(simulationResult(sim)),
+			sim.Name,
+			sim.Enforcement,
+			sim.Source,
+			sim.Reason,
+		})
+	}
+	table.Render()
+
+	for _, sim := range sims {
+		if !sim.Applies || len(sim.Rules) == 0 {
+			continue
+		}
+		r.writeLine("")
+		r.writeLine(fmt.Sprintf("Rules of ruleset '%s' (%s):", sim.Name, sim.Source))
+		rules := r.newTableWriter([]string{"OUTCOME", "RULE", "REASON"})
+		for _, rule := range sim.Rules {
+			rules.Append([]string{r.colorizeRuleOutcome(rule.Outcome), rule.Type, rule.Reason})
+		}
+		rules.Render()
+	}
+}
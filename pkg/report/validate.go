@@ -0,0 +1,47 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+)
+
+// RenderValidationErrors renders the problems found in ruleset files, one per line, or as JSON when an exporter is set
+func (r *Renderer) RenderValidationErrors(files []string, errs []*rulekit.ValidationError) {
+	if r.exporter != nil {
+		r.RenderExportedData(errs)
+		return
+	}
+
+	if len(errs) == 0 {
+		r.writeLine(fmt.Sprintf("No problems found in %d ruleset files.", len(files)))
+		return
+	}
+
+	for _, e := range errs {
+		location := fmt.Sprintf("%s: %s:", e.File, e.Path)
+		if r.Color {
+			location = color.New(color.Bold).Sprint(location)
+		}
+		r.writeLine(fmt.Sprintf("%s %s", location, r.colorizeError(e.Message)))
+	}
+}
+
+func (r *Renderer) colorizeError(s string) string {
+	if !r.Color {
+		return s
+	}
+	return color.RedString(s)
+}
+
+// RenderValidationAnnotations writes a GitHub Actions error annotation for every problem found in ruleset files.
+// Annotations are written to stderr so that they do not mix with the report or JSON output.
+func (r *Renderer) RenderValidationAnnotations(errs []*rulekit.ValidationError) {
+	for _, e := range errs {
+		file, _, _ := strings.Cut(e.File, "#")
+		properties := []string{"file=" + escapeAnnotationProperty(file), "title=" + escapeAnnotationProperty("Invalid ruleset")}
+		fmt.Fprintf(r.IO.ErrOut, "::error %s::%s\n", strings.Join(properties, ","), escapeAnnotationData(fmt.Sprintf("%s: %s", e.Path, e.Message)))
+	}
+}
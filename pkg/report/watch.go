@@ -0,0 +1,56 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/srz-zumix/gh-rule-kit/pkg/rulekit"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/render"
+)
+
+// RenderRuleSuiteEvent renders a rule suite reported by a watch as a line followed by a line per rule evaluation
+// that did not pass, or as a single JSON line when jsonLines is set
+func (r *Renderer) RenderRuleSuiteEvent(suite *gh.RuleSuite, jsonLines bool) error {
+	if jsonLines {
+		data, err := json.Marshal(suite)
+		if err != nil {
+			return err
+		}
+		r.writeLine(string(data))
+		return nil
+	}
+
+	pushedAt := ""
+	if suite.PushedAt != nil {
+		pushedAt = suite.PushedAt.Format(time.RFC3339)
+	}
+	fields := []string{pushedAt, "#" + render.ToString(suite.ID)}
+	if name := render.ToString(suite.RepositoryName); name != "" {
+		fields = append(fields, name)
+	}
+	fields = append(fields,
+		strings.TrimPrefix(render.ToString(suite.Ref), "refs/heads/"),
+		render.ToString(suite.ActorName),
+		fmt.Sprintf("result=%s", render.ToString(suite.Result)),
+		fmt.Sprintf("evaluation=%s", render.ToString(suite.EvaluationResult)),
+	)
+	r.writeLine(strings.Join(fields, " "))
+	for _, evaluation := range suite.RuleEvaluations {
+		if render.ToString(evaluation.Result) == rulekit.RuleSuiteResultPass {
+			continue
+		}
+		source := ""
+		if evaluation.RuleSource != nil {
+			source = render.ToString(evaluation.RuleSource.Name)
+		}
+		line := fmt.Sprintf("  %s %s: %s (%s)", source, render.ToString(evaluation.RuleType), render.ToString(evaluation.Result), render.ToString(evaluation.EnforcementMode))
+		if details := render.ToString(evaluation.Details); details != "" {
+			line += ": " + details
+		}
+		r.writeLine(line)
+	}
+	return nil
+}
@@ -0,0 +1,370 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"gopkg.in/yaml.v3"
+)
+
+// ActorNone is the destination of an actor mapping that drops the actor from the migrated rulesets
+const ActorNone = "none"
+
+// builtinRepositoryRoles are the IDs of the repository roles every organization has
+var builtinRepositoryRoles = map[string]int64{
+	"maintain": 2,
+	"write":    4,
+	"admin":    5,
+}
+
+// ActorMapping maps a bypass actor of the source to the destination.
+// Source and destination are IDs or names: team slugs, app slugs, or repository role names.
+type ActorMapping struct {
+	Type        string `json:"type" yaml:"type"`
+	Source      string `json:"source" yaml:"source"`
+	Destination string `json:"destination" yaml:"destination"`
+}
+
+// ActorMap is the content of an actor mapping file
+type ActorMap struct {
+	Actors []*ActorMapping `json:"actors" yaml:"actors"`
+}
+
+// LoadActorMap reads an actor mapping file in JSON or YAML
+func LoadActorMap(path string) (*ActorMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var actorMap ActorMap
+	if err := yaml.Unmarshal(data, &actorMap); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, mapping := range actorMap.Actors {
+		if !isBypassActorType(mapping.Type) {
+			return nil, fmt.Errorf("%s: actors[%d]: unknown actor type '%s'", path, i, mapping.Type)
+		}
+		if mapping.Source == "" || mapping.Destination == "" {
+			return nil, fmt.Errorf("%s: actors[%d]: source and destination are required", path, i)
+		}
+	}
+	return &actorMap, nil
+}
+
+// bypassActorTypes are the actor types of bypass actors, shared by actor maps and ruleset validation
+var bypassActorTypes = []string{
+	string(github.BypassActorTypeIntegration),
+	string(github.BypassActorTypeOrganizationAdmin),
+	string(github.BypassActorTypeRepositoryRole),
+	string(github.BypassActorTypeTeam),
+	string(github.BypassActorTypeDeployKey),
+}
+
+func isBypassActorType(actorType string) bool {
+	return slices.Contains(bypassActorTypes, actorType)
+}
+
+// ActorResolver maps the bypass actors of migrated rulesets with an actor map.
+// Actors without a mapping keep their ID when it is valid in the destination, or are matched by name;
+// actors that cannot be resolved are reported as an error instead of being dropped.
+type ActorResolver struct {
+	actorMap *ActorMap
+	src      *gh.GitHubClient
+	srcRepo  repository.Repository
+	dst      *gh.GitHubClient
+	dstRepo  repository.Repository
+	// enterprise is set when the owners are enterprises, which have no teams or custom repository roles to look up
+	enterprise bool
+
+	ids   map[string]int64
+	roles map[string]map[string]int64
+}
+
+// NewActorResolver creates a resolver that maps actors from the source to the destination
+func NewActorResolver(actorMap *ActorMap, src *gh.GitHubClient, srcRepo repository.Repository, dst *gh.GitHubClient, dstRepo repository.Repository) *ActorResolver {
+	return &ActorResolver{
+		actorMap: actorMap,
+		src:      src,
+		srcRepo:  srcRepo,
+		dst:      dst,
+		dstRepo:  dstRepo,
+		ids:      map[string]int64{},
+		roles:    map[string]map[string]int64{},
+	}
+}
+
+// IntegrationMap is the integration ID, nil for any integration, of each required status check by context that was
+// set by an actor map
+type IntegrationMap map[string]*int64
+
+// MapMigrateConfig rewrites the bypass actors and the integrations of required status checks of a migration config
+// for the destination. It fails with every actor that could not be resolved. The mapped integrations are returned so
+// that they can be restored after the import, which resolves integrations again from the check runs of the destination.
+func (r *ActorResolver) MapMigrateConfig(ctx context.Context, config *gh.RepositoryRulesetMigrateConfig) (IntegrationMap, error) {
+	ruleset := config.Ruleset
+	var unresolved []string
+	actors := make([]*github.BypassActor, 0, len(ruleset.BypassActors))
+	for _, actor := range ruleset.BypassActors {
+		if actor.ActorType == nil {
+			actors = append(actors, actor)
+			continue
+		}
+		actorType := string(*actor.ActorType)
+		id, drop, err := r.resolve(ctx, config, actorType, actor.GetActorID())
+		if err != nil {
+			unresolved = append(unresolved, fmt.Sprintf("%s %s: %v", actorType, r.describeSource(config, actorType, actor.GetActorID()), err))
+			continue
+		}
+		if drop {
+			continue
+		}
+		if actor.ActorID != nil {
+			actor.ActorID = github.Ptr(id)
+		}
+		actors = append(actors, actor)
+	}
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf("unresolved bypass actors: %s", strings.Join(unresolved, "; "))
+	}
+	ruleset.BypassActors = actors
+
+	integrations := IntegrationMap{}
+	if ruleset.Rules != nil && ruleset.Rules.RequiredStatusChecks != nil {
+		for _, check := range ruleset.Rules.RequiredStatusChecks.RequiredStatusChecks {
+			if check.IntegrationID == nil {
+				continue
+			}
+			srcID := *check.IntegrationID
+			mapping, err := r.find(ctx, config, string(github.BypassActorTypeIntegration), srcID)
+			if err != nil {
+				return nil, err
+			}
+			if mapping == nil {
+				continue
+			}
+			if mapping.Destination == ActorNone {
+				check.IntegrationID = nil
+				integrations[check.Context] = nil
+				continue
+			}
+			id, err := r.resolveDestination(ctx, string(github.BypassActorTypeIntegration), mapping.Destination)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve integration '%s' of status check '%s': %w", mapping.Destination, check.Context, err)
+			}
+			check.IntegrationID = github.Ptr(id)
+			integrations[check.Context] = github.Ptr(id)
+			// Keep the check run of the source app, so that the import can still match it by app
+			if checkRun, ok := config.CheckRuns[srcID]; ok {
+				config.CheckRuns[id] = checkRun
+			}
+		}
+	}
+	return integrations, nil
+}
+
+// resolve returns the destination ID of an actor, or true when the actor is dropped
+func (r *ActorResolver) resolve(ctx context.Context, config *gh.RepositoryRulesetMigrateConfig, actorType string, srcID int64) (int64, bool, error) {
+	mapping, err := r.find(ctx, config, actorType, srcID)
+	if err != nil {
+		return 0, false, err
+	}
+	if mapping != nil {
+		if mapping.Destination == ActorNone {
+			return 0, true, nil
+		}
+		id, err := r.resolveDestination(ctx, actorType, mapping.Destination)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to resolve '%s' in the destination: %w", mapping.Destination, err)
+		}
+		return id, false, nil
+	}
+
+	switch github.BypassActorType(actorType) {
+	case github.BypassActorTypeOrganizationAdmin, github.BypassActorTypeDeployKey:
+		return srcID, false, nil
+	case github.BypassActorTypeTeam:
+		team, ok := config.Teams[srcID]
+		if !ok {
+			return 0, false, fmt.Errorf("team is not found in the source")
+		}
+		id, err := r.resolveDestination(ctx, actorType, team.GetSlug())
+		if err != nil {
+			return 0, false, fmt.Errorf("no mapping and no team with the same slug in the destination")
+		}
+		return id, false, nil
+	case github.BypassActorTypeRepositoryRole:
+		for _, builtin := range builtinRepositoryRoles {
+			if builtin == srcID {
+				return srcID, false, nil
+			}
+		}
+		if r.enterprise {
+			return 0, false, fmt.Errorf("custom repository roles of enterprise rulesets cannot be matched by name, map the role by ID")
+		}
+		name, err := r.roleName(ctx, r.src, r.srcRepo.Host, r.srcRepo.Owner, srcID)
+		if err != nil {
+			return 0, false, err
+		}
+		id, err := r.resolveDestination(ctx, actorType, name)
+		if err != nil {
+			return 0, false, fmt.Errorf("no mapping and no repository role named '%s' in the destination", name)
+		}
+		return id, false, nil
+	case github.BypassActorTypeIntegration:
+		// App IDs are only valid on the host they were registered on
+		if r.srcRepo.Host == r.dstRepo.Host {
+			return srcID, false, nil
+		}
+		return 0, false, fmt.Errorf("no mapping for an app of another host")
+	}
+	return 0, false, fmt.Errorf("no mapping")
+}
+
+// find returns the mapping whose source is the actor, by ID or by name in the source
+func (r *ActorResolver) find(ctx context.Context, config *gh.RepositoryRulesetMigrateConfig, actorType string, srcID int64) (*ActorMapping, error) {
+	if r.actorMap == nil {
+		return nil, nil
+	}
+	for _, mapping := range r.actorMap.Actors {
+		if mapping.Type != actorType {
+			continue
+		}
+		if id, err := strconv.ParseInt(mapping.Source, 10, 64); err == nil {
+			if id == srcID {
+				return mapping, nil
+			}
+			continue
+		}
+		id, err := r.resolveSource(ctx, config, actorType, mapping.Source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve '%s' in the source: %w", mapping.Source, err)
+		}
+		if id == srcID {
+			return mapping, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveSource returns the ID of an actor name in the source
+func (r *ActorResolver) resolveSource(ctx context.Context, config *gh.RepositoryRulesetMigrateConfig, actorType string, name string) (int64, error) {
+	if actorType == string(github.BypassActorTypeTeam) {
+		for id, team := range config.Teams {
+			if team.GetSlug() == name {
+				return id, nil
+			}
+		}
+	}
+	return r.lookup(ctx, r.src, r.srcRepo.Host, r.srcRepo.Owner, actorType, name)
+}
+
+// resolveDestination returns the ID of an actor ID or name in the destination
+func (r *ActorResolver) resolveDestination(ctx context.Context, actorType string, value string) (int64, error) {
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return id, nil
+	}
+	return r.lookup(ctx, r.dst, r.dstRepo.Host, r.dstRepo.Owner, actorType, value)
+}
+
+// lookup returns the ID of a named actor of an owner, cached per host and owner
+func (r *ActorResolver) lookup(ctx context.Context, g *gh.GitHubClient, host string, owner string, actorType string, name string) (int64, error) {
+	key := strings.Join([]string{host, owner, actorType, name}, "/")
+	if id, ok := r.ids[key]; ok {
+		return id, nil
+	}
+	id, err := r.lookupID(ctx, g, host, owner, actorType, name)
+	if err != nil {
+		return 0, err
+	}
+	r.ids[key] = id
+	return id, nil
+}
+
+func (r *ActorResolver) lookupID(ctx context.Context, g *gh.GitHubClient, host string, owner string, actorType string, name string) (int64, error) {
+	if r.enterprise {
+		switch github.BypassActorType(actorType) {
+		case github.BypassActorTypeTeam:
+			return 0, fmt.Errorf("teams of enterprise rulesets cannot be resolved by name, map them by ID")
+		case github.BypassActorTypeRepositoryRole:
+			if _, ok := builtinRepositoryRoles[name]; !ok {
+				return 0, fmt.Errorf("custom repository roles of enterprise rulesets cannot be resolved by name, map them by ID")
+			}
+		}
+	}
+	switch github.BypassActorType(actorType) {
+	case github.BypassActorTypeTeam:
+		team, err := g.GetTeamBySlug(ctx, owner, name)
+		if err != nil {
+			return 0, err
+		}
+		return team.GetID(), nil
+	case github.BypassActorTypeIntegration:
+		app, _, err := g.GetClient().Apps.Get(ctx, name)
+		if err != nil {
+			return 0, err
+		}
+		return app.GetID(), nil
+	case github.BypassActorTypeRepositoryRole:
+		if id, ok := builtinRepositoryRoles[name]; ok {
+			return id, nil
+		}
+		roles, err := r.customRoles(ctx, g, host, owner)
+		if err != nil {
+			return 0, err
+		}
+		if id, ok := roles[name]; ok {
+			return id, nil
+		}
+		return 0, fmt.Errorf("repository role '%s' not found", name)
+	}
+	return 0, fmt.Errorf("%s actors cannot be resolved by name", actorType)
+}
+
+// roleName returns the name of a custom repository role of an owner
+func (r *ActorResolver) roleName(ctx context.Context, g *gh.GitHubClient, host string, owner string, id int64) (string, error) {
+	roles, err := r.customRoles(ctx, g, host, owner)
+	if err != nil {
+		return "", err
+	}
+	for name, roleID := range roles {
+		if roleID == id {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("repository role is not found in the source")
+}
+
+// customRoles returns the IDs of the custom repository roles of an organization by name, cached per host and owner
+func (r *ActorResolver) customRoles(ctx context.Context, g *gh.GitHubClient, host string, owner string) (map[string]int64, error) {
+	key := host + "/" + owner
+	if roles, ok := r.roles[key]; ok {
+		return roles, nil
+	}
+	result, _, err := g.GetClient().Organizations.ListCustomRepoRoles(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom repository roles: %w", err)
+	}
+	roles := map[string]int64{}
+	for _, role := range result.CustomRepoRoles {
+		roles[role.GetName()] = role.GetID()
+	}
+	r.roles[key] = roles
+	return roles, nil
+}
+
+// describeSource returns the source name of an actor for error messages
+func (r *ActorResolver) describeSource(config *gh.RepositoryRulesetMigrateConfig, actorType string, id int64) string {
+	if actorType == string(github.BypassActorTypeTeam) {
+		if team, ok := config.Teams[id]; ok {
+			return fmt.Sprintf("'%s' (id: %d)", team.GetSlug(), id)
+		}
+	}
+	return fmt.Sprintf("(id: %d)", id)
+}
@@ -0,0 +1,124 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// rulesetAPI groups the ruleset operations of a single repository, organization or enterprise,
+// so that the same logic can be shared between them
+type rulesetAPI struct {
+	list   func(ctx context.Context) ([]*github.RepositoryRuleset, error)
+	get    func(ctx context.Context, id int64) (*github.RepositoryRuleset, error)
+	create func(ctx context.Context, ruleset *github.RepositoryRuleset) (*github.RepositoryRuleset, error)
+	update func(ctx context.Context, id int64, ruleset *github.RepositoryRuleset) (*github.RepositoryRuleset, error)
+	delete func(ctx context.Context, id int64) error
+	path   func(id int64) string
+	target snapshotTarget
+	client *gh.GitHubClient
+}
+
+// newRulesetAPI returns the ruleset operations of a repository, or of an organization when repo.Name is empty
+func newRulesetAPI(g *gh.GitHubClient, repo repository.Repository, includesParents bool) *rulesetAPI {
+	return &rulesetAPI{
+		list: func(ctx context.Context) ([]*github.RepositoryRuleset, error) {
+			return gh.ListRulesets(ctx, g, repo, includesParents)
+		},
+		get: func(ctx context.Context, id int64) (*github.RepositoryRuleset, error) {
+			return gh.GetRuleset(ctx, g, repo, id, includesParents)
+		},
+		create: func(ctx context.Context, ruleset *github.RepositoryRuleset) (*github.RepositoryRuleset, error) {
+			return gh.CreateRuleset(ctx, g, repo, ruleset)
+		},
+		update: func(ctx context.Context, id int64, ruleset *github.RepositoryRuleset) (*github.RepositoryRuleset, error) {
+			return gh.UpdateRuleset(ctx, g, repo, id, ruleset)
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return gh.DeleteRuleset(ctx, g, repo, id)
+		},
+		path: func(id int64) string {
+			return rulesetPath(repo, id)
+		},
+		target: newSnapshotTarget(g, repo),
+		client: g,
+	}
+}
+
+// rulesetPath returns the REST path of a repository or organization (repo.Name is empty) ruleset
+func rulesetPath(repo repository.Repository, rulesetID int64) string {
+	if repo.Name == "" {
+		return fmt.Sprintf("orgs/%s/rulesets/%d", repo.Owner, rulesetID)
+	}
+	return fmt.Sprintf("repos/%s/%s/rulesets/%d", repo.Owner, repo.Name, rulesetID)
+}
+
+// updateFields updates only the given top-level fields of a ruleset. Unlike update, which sends the whole ruleset,
+// it leaves the other fields as they are on GitHub, including changes made since the ruleset was read.
+func (api *rulesetAPI) updateFields(ctx context.Context, id int64, fields map[string]any) (*github.RepositoryRuleset, error) {
+	client := api.client.GetClient()
+	req, err := client.NewRequest("PUT", api.path(id), fields)
+	if err != nil {
+		return nil, err
+	}
+	var updated github.RepositoryRuleset
+	if _, err := client.Do(ctx, req, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// findByName returns the ruleset with the given name, or nil when none matches
+func (api *rulesetAPI) findByName(ctx context.Context, name string) (*github.RepositoryRuleset, error) {
+	rulesets, err := api.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ruleset := range rulesets {
+		if ruleset.Name == name {
+			return ruleset, nil
+		}
+	}
+	return nil, nil
+}
+
+// find returns the full ruleset with the given ID or name
+func (api *rulesetAPI) find(ctx context.Context, idOrName string) (*github.RepositoryRuleset, error) {
+	if id, err := strconv.ParseInt(idOrName, 10, 64); err == nil {
+		return api.get(ctx, id)
+	}
+	found, err := api.findByName(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("ruleset not found with name '%s'", idOrName)
+	}
+	return api.get(ctx, found.GetID())
+}
+
+// findConfig returns the live ruleset matching a ruleset configuration by ID and then by name, or nil when none matches
+func (api *rulesetAPI) findConfig(ctx context.Context, config *gh.RepositoryRulesetConfig) (*github.RepositoryRuleset, error) {
+	if config.ID != nil {
+		if ruleset, err := api.get(ctx, *config.ID); err == nil {
+			return ruleset, nil
+		}
+	}
+	return api.findByName(ctx, config.Name)
+}
+
+// exportCurrent fetches the full ruleset and converts it to the export format. It returns nil when ruleset is nil.
+func (api *rulesetAPI) exportCurrent(ctx context.Context, ruleset *github.RepositoryRuleset) (*gh.RepositoryRulesetConfig, error) {
+	if ruleset == nil {
+		return nil, nil
+	}
+	full, err := api.get(ctx, ruleset.GetID())
+	if err != nil {
+		return nil, err
+	}
+	return gh.ExportRuleset(full), nil
+}
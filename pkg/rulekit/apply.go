@@ -0,0 +1,113 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// ApplyAction is the operation to reconcile a ruleset with its desired state
+type ApplyAction string
+
+const (
+	ApplyActionCreate   ApplyAction = "create"
+	ApplyActionUpdate   ApplyAction = "update"
+	ApplyActionDelete   ApplyAction = "delete"
+	ApplyActionNoChange ApplyAction = "no-change"
+)
+
+// ApplyChange is a single planned operation of an apply run
+type ApplyChange struct {
+	Action  ApplyAction
+	Name    string
+	Path    string
+	Desired *gh.RepositoryRulesetConfig
+	Current *github.RepositoryRuleset
+}
+
+// PlanApply compares the desired ruleset files with the live rulesets of a repository or organization
+// (organization when repo.Name is empty) and returns the operations needed to reconcile them.
+// A file matches a live ruleset by ID first and then by name. Live rulesets without a matching file
+// are planned for deletion only when prune is true.
+func PlanApply(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, files []*ConfigFile, prune bool) ([]*ApplyChange, error) {
+	live, err := gh.ListRulesets(ctx, g, repo, false)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*github.RepositoryRuleset)
+	byName := make(map[string]*github.RepositoryRuleset)
+	for _, ruleset := range live {
+		byID[ruleset.GetID()] = ruleset
+		byName[ruleset.Name] = ruleset
+	}
+
+	matched := make(map[int64]string)
+	var changes []*ApplyChange
+	for _, file := range files {
+		config := file.Config
+		current, ok := byName[config.Name]
+		if config.ID != nil {
+			if r, found := byID[*config.ID]; found {
+				current, ok = r, true
+			}
+		}
+		if !ok {
+			changes = append(changes, &ApplyChange{Action: ApplyActionCreate, Name: config.Name, Path: file.Path, Desired: config})
+			continue
+		}
+		if path, dup := matched[current.GetID()]; dup {
+			return nil, fmt.Errorf("%s and %s both match ruleset '%s'", path, file.Path, current.Name)
+		}
+		matched[current.GetID()] = file.Path
+
+		// The list API does not return rules and conditions, so fetch the full ruleset to compare
+		full, err := gh.GetRuleset(ctx, g, repo, current.GetID(), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ruleset '%s': %w", current.Name, err)
+		}
+		equal, err := EqualConfig(gh.ExportRuleset(full), config)
+		if err != nil {
+			return nil, err
+		}
+		action := ApplyActionUpdate
+		if equal {
+			action = ApplyActionNoChange
+		}
+		changes = append(changes, &ApplyChange{Action: action, Name: config.Name, Path: file.Path, Desired: config, Current: full})
+	}
+
+	if prune {
+		for _, ruleset := range live {
+			if _, ok := matched[ruleset.GetID()]; ok {
+				continue
+			}
+			full, err := gh.GetRuleset(ctx, g, repo, ruleset.GetID(), false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ruleset '%s': %w", ruleset.Name, err)
+			}
+			changes = append(changes, &ApplyChange{Action: ApplyActionDelete, Name: ruleset.Name, Current: full})
+		}
+	}
+	return changes, nil
+}
+
+// ExecuteApplyChange performs a single planned operation. Rulesets are backed up before they are updated or deleted.
+func ExecuteApplyChange(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, change *ApplyChange) (*github.RepositoryRuleset, error) {
+	api := newRulesetAPI(g, repo, false)
+	switch change.Action {
+	case ApplyActionCreate:
+		return api.create(ctx, gh.ImportRuleset(change.Desired, nil))
+	case ApplyActionUpdate:
+		if err := api.backup(ctx, change.Current.GetID(), "apply"); err != nil {
+			return nil, err
+		}
+		return api.update(ctx, change.Current.GetID(), gh.ImportRuleset(change.Desired, nil))
+	case ApplyActionDelete:
+		return change.Current, api.remove(ctx, change.Current.GetID(), "apply")
+	default:
+		return change.Current, nil
+	}
+}
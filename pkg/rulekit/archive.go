@@ -0,0 +1,226 @@
+package rulekit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// InsightArchiveDirEnv is the environment variable that overrides the rule suite archive directory
+const InsightArchiveDirEnv = "GH_RULE_KIT_INSIGHT_DIR"
+
+// InsightArchiveDir returns the directory rule suites are archived to: $GH_RULE_KIT_INSIGHT_DIR, otherwise
+// gh-rule-kit/insights under $XDG_STATE_HOME or ~/.local/state
+func InsightArchiveDir() (string, error) {
+	if dir := os.Getenv(InsightArchiveDirEnv); dir != "" {
+		return dir, nil
+	}
+	return stateDir("insights")
+}
+
+// RuleSuiteArchive is a local store of the rule suites of a repository or organization, one JSON rule suite
+// with its rule evaluations per line
+type RuleSuiteArchive struct {
+	path string
+}
+
+// OpenRuleSuiteArchive returns the archive of a repository, or of an organization when repo.Name is empty,
+// stored as HOST/OWNER[/REPO]/rule-suites.jsonl in the archive directory
+func OpenRuleSuiteArchive(g *gh.GitHubClient, repo repository.Repository) (*RuleSuiteArchive, error) {
+	dir, err := InsightArchiveDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find insight archive directory: %w", err)
+	}
+	target := newSnapshotTarget(g, repo)
+	return &RuleSuiteArchive{path: filepath.Join(dir, target.host, target.owner, target.repo, "rule-suites.jsonl")}, nil
+}
+
+// Path returns the file the archive is stored in
+func (a *RuleSuiteArchive) Path() string {
+	return a.path
+}
+
+// Load reads all rule suites of the archive in the order they were archived. A missing archive is empty.
+func (a *RuleSuiteArchive) Load() ([]*gh.RuleSuite, error) {
+	f, err := os.Open(a.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var suites []*gh.RuleSuite
+	decoder := json.NewDecoder(f)
+	for {
+		var suite gh.RuleSuite
+		if err := decoder.Decode(&suite); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: %w", a.path, err)
+		}
+		suites = append(suites, &suite)
+	}
+	return suites, nil
+}
+
+// append writes rule suites to the end of the archive
+func (a *RuleSuiteArchive) append(suites []*gh.RuleSuite) error {
+	if err := os.MkdirAll(filepath.Dir(a.path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(f)
+	for _, suite := range suites {
+		if err := encoder.Encode(suite); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// ArchiveRuleSuites fetches the rule suites of the last month that are not in the archive yet, along with their
+// rule evaluations, and appends them oldest first. It returns the number of rule suites added and archived in total.
+// GitHub only lists rule suites of up to a month back, so archive at least that often to keep the archive complete.
+func ArchiveRuleSuites(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, archive *RuleSuiteArchive) (int, int, error) {
+	archived, err := archive.Load()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read archive: %w", err)
+	}
+	known := make(map[int64]bool, len(archived))
+	for _, suite := range archived {
+		known[valueOf(suite.ID)] = true
+	}
+
+	options := &gh.ListRuleSuitesOptions{TimePeriod: "month"}
+	var suites []*gh.RuleSuite
+	if repo.Name == "" {
+		suites, err = gh.ListOrgRuleSuites(ctx, g, repo, options)
+	} else {
+		suites, err = gh.ListRepositoryRuleSuites(ctx, g, repo, options)
+	}
+	if err != nil {
+		return 0, len(archived), fmt.Errorf("failed to list rule suites: %w", err)
+	}
+	var fresh []*gh.RuleSuite
+	for _, suite := range suites {
+		if !known[valueOf(suite.ID)] {
+			fresh = append(fresh, suite)
+		}
+	}
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return valueOf(fresh[i].ID) < valueOf(fresh[j].ID)
+	})
+
+	details := GetRuleSuiteDetails(ctx, g, repo, fresh)
+	if err := archive.append(details); err != nil {
+		return 0, len(archived), fmt.Errorf("failed to write archive: %w", err)
+	}
+	return len(details), len(archived) + len(details), nil
+}
+
+// RuleSuiteQuery selects archived rule suites. Zero fields match everything; To is exclusive.
+type RuleSuiteQuery struct {
+	From      time.Time
+	To        time.Time
+	Ref       string
+	ActorName string
+	Result    string
+}
+
+// NewRuleSuiteQuery returns a query of rule suites pushed from from to to, inclusive, matching the filters of options.
+// Dates are given as YYYY-MM-DD or RFC 3339; either may be empty.
+func NewRuleSuiteQuery(from string, to string, options *gh.ListRuleSuitesOptions) (*RuleSuiteQuery, error) {
+	query := &RuleSuiteQuery{Ref: options.Ref, ActorName: options.ActorName, Result: options.RuleSuiteResult}
+	if from != "" {
+		t, _, err := parseQueryDate(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --from date '%s'", from)
+		}
+		query.From = t
+	}
+	if to != "" {
+		t, dateOnly, err := parseQueryDate(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --to date '%s'", to)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		} else {
+			t = t.Add(time.Nanosecond)
+		}
+		query.To = t
+	}
+	return query, nil
+}
+
+func parseQueryDate(value string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+// matches reports whether a rule suite is selected by the query
+func (q *RuleSuiteQuery) matches(suite *gh.RuleSuite) bool {
+	if suite.PushedAt != nil {
+		if !q.From.IsZero() && suite.PushedAt.Before(q.From) {
+			return false
+		}
+		if !q.To.IsZero() && !suite.PushedAt.Before(q.To) {
+			return false
+		}
+	} else if !q.From.IsZero() || !q.To.IsZero() {
+		return false
+	}
+	if q.Ref != "" {
+		ref := valueOf(suite.Ref)
+		if ref != q.Ref && ref != "refs/heads/"+q.Ref {
+			return false
+		}
+	}
+	if q.ActorName != "" && !strings.EqualFold(valueOf(suite.ActorName), q.ActorName) {
+		return false
+	}
+	if q.Result != "" && valueOf(suite.Result) != q.Result {
+		return false
+	}
+	return true
+}
+
+// QueryRuleSuiteArchive returns the archived rule suites of a repository or organization (organization when repo.Name
+// is empty) selected by query, newest first like the rule suites API
+func QueryRuleSuiteArchive(g *gh.GitHubClient, repo repository.Repository, query *RuleSuiteQuery) ([]*gh.RuleSuite, error) {
+	archive, err := OpenRuleSuiteArchive(g, repo)
+	if err != nil {
+		return nil, err
+	}
+	suites, err := archive.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	var selected []*gh.RuleSuite
+	for i := len(suites) - 1; i >= 0; i-- {
+		if query.matches(suites[i]) {
+			selected = append(selected, suites[i])
+		}
+	}
+	return selected, nil
+}
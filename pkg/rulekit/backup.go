@@ -0,0 +1,224 @@
+package rulekit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/guardrails"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+)
+
+// BackupDirEnv is the environment variable that overrides the backup directory
+const BackupDirEnv = "GH_RULE_KIT_BACKUP_DIR"
+
+// SnapshotScope is the kind of owner a ruleset snapshot was taken from
+type SnapshotScope string
+
+const (
+	SnapshotScopeRepository   SnapshotScope = "repository"
+	SnapshotScopeOrganization SnapshotScope = "organization"
+	SnapshotScopeEnterprise   SnapshotScope = "enterprise"
+)
+
+// Snapshot is a copy of a ruleset taken before it was overwritten or removed
+type Snapshot struct {
+	Operation string                      `json:"operation"`
+	CreatedAt time.Time                   `json:"created_at"`
+	Scope     SnapshotScope               `json:"scope"`
+	Host      string                      `json:"host"`
+	Owner     string                      `json:"owner"`
+	Repo      string                      `json:"repo,omitempty"`
+	Ruleset   *gh.RepositoryRulesetConfig `json:"ruleset"`
+}
+
+// snapshotTarget identifies the owner of the rulesets of a rulesetAPI in snapshots
+type snapshotTarget struct {
+	scope SnapshotScope
+	host  string
+	owner string
+	repo  string
+}
+
+// newSnapshotTarget returns the snapshot target of a repository, or of an organization when repo.Name is empty
+func newSnapshotTarget(g *gh.GitHubClient, repo repository.Repository) snapshotTarget {
+	target := snapshotTarget{scope: SnapshotScopeRepository, host: repo.Host, owner: repo.Owner, repo: repo.Name}
+	if repo.Name == "" {
+		target.scope = SnapshotScopeOrganization
+	}
+	if target.host == "" {
+		target.host = clientHost(g)
+	}
+	return target
+}
+
+// clientHost returns the GitHub host a client talks to, such as github.com for api.github.com
+func clientHost(g *gh.GitHubClient) string {
+	host := g.GetClient().BaseURL.Hostname()
+	return strings.TrimPrefix(host, "api.")
+}
+
+// BackupDir returns the directory snapshots are written to: $GH_RULE_KIT_BACKUP_DIR, otherwise
+// gh-rule-kit/backups under $XDG_STATE_HOME or ~/.local/state
+func BackupDir() (string, error) {
+	if dir := os.Getenv(BackupDirEnv); dir != "" {
+		return dir, nil
+	}
+	return stateDir("backups")
+}
+
+// stateDir returns a directory of gh-rule-kit state under $XDG_STATE_HOME or ~/.local/state
+func stateDir(name string) (string, error) {
+	state := os.Getenv("XDG_STATE_HOME")
+	if state == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		state = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(state, "gh-rule-kit", name), nil
+}
+
+// backup fetches the full ruleset and writes a snapshot of it before operation overwrites or removes it.
+// Nothing is written in read-only mode, where the ruleset is not changed.
+func (api *rulesetAPI) backup(ctx context.Context, id int64, operation string) error {
+	if guardrails.IsReadonly() {
+		return nil
+	}
+	ruleset, err := api.get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get ruleset for backup: %w", err)
+	}
+	snapshot := &Snapshot{
+		Operation: operation,
+		CreatedAt: time.Now().UTC(),
+		Scope:     api.target.scope,
+		Host:      api.target.host,
+		Owner:     api.target.owner,
+		Repo:      api.target.repo,
+		Ruleset:   gh.ExportRuleset(ruleset),
+	}
+	path, err := WriteSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to back up ruleset '%s': %w", ruleset.Name, err)
+	}
+	logger.Info("Backed up ruleset", "name", ruleset.Name, "operation", operation, "path", path)
+	return nil
+}
+
+// WriteSnapshot writes a snapshot to the backup directory and returns its path.
+// Snapshots are stored as HOST/OWNER[/REPO]/TIMESTAMP-OPERATION-NAME.json, or HOST/enterprises/ENTERPRISE/... for enterprises.
+func WriteSnapshot(snapshot *Snapshot) (string, error) {
+	dir, err := BackupDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find backup directory: %w", err)
+	}
+	switch snapshot.Scope {
+	case SnapshotScopeEnterprise:
+		dir = filepath.Join(dir, snapshot.Host, "enterprises", snapshot.Owner)
+	default:
+		dir = filepath.Join(dir, snapshot.Host, snapshot.Owner, snapshot.Repo)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	name := Slugify(snapshot.Ruleset.Name)
+	if name == "" {
+		name = "ruleset"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.json", snapshot.CreatedAt.Format("20060102T150405.000Z"), snapshot.Operation, name))
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadSnapshot reads a snapshot written by WriteSnapshot
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if snapshot.Ruleset == nil || snapshot.Owner == "" {
+		return nil, fmt.Errorf("%s: not a ruleset snapshot", path)
+	}
+	switch snapshot.Scope {
+	case SnapshotScopeRepository:
+		if snapshot.Repo == "" {
+			return nil, fmt.Errorf("%s: repository snapshot without repository name", path)
+		}
+	case SnapshotScopeOrganization, SnapshotScopeEnterprise:
+	default:
+		return nil, fmt.Errorf("%s: unknown snapshot scope '%s'", path, snapshot.Scope)
+	}
+	return &snapshot, nil
+}
+
+// Repository returns the repository, organization (Name is empty) or enterprise the snapshot was taken from
+func (s *Snapshot) Repository() repository.Repository {
+	return repository.Repository{Host: s.Host, Owner: s.Owner, Name: s.Repo}
+}
+
+// Target returns the snapshot owner for display, such as github.com/owner/repo
+func (s *Snapshot) Target() string {
+	parts := []string{s.Host, s.Owner}
+	if s.Scope == SnapshotScopeEnterprise {
+		parts = []string{s.Host, "enterprises", s.Owner}
+	}
+	if s.Repo != "" {
+		parts = append(parts, s.Repo)
+	}
+	return strings.Join(parts, "/")
+}
+
+func (s *Snapshot) api(g *gh.GitHubClient) *rulesetAPI {
+	if s.Scope == SnapshotScopeEnterprise {
+		return newEnterpriseRulesetAPI(g, s.Owner)
+	}
+	return newRulesetAPI(g, s.Repository(), false)
+}
+
+// PlanRestore computes the plan of restoring a snapshot. The ruleset is matched by ID and then by name.
+func PlanRestore(ctx context.Context, g *gh.GitHubClient, snapshot *Snapshot) (*Plan, error) {
+	return snapshot.api(g).planImport(ctx, snapshot.Target(), snapshot.Ruleset, true)
+}
+
+// RestoreSnapshot puts a snapshot back, updating the ruleset matching it by ID and then by name or creating it
+// when it was deleted. The current version is backed up first.
+func RestoreSnapshot(ctx context.Context, g *gh.GitHubClient, snapshot *Snapshot) (*github.RepositoryRuleset, ApplyAction, error) {
+	return snapshot.api(g).importConfigAs(ctx, snapshot.Ruleset, true, "restore")
+}
+
+// RemoveRuleset backs up a ruleset of a repository or organization (organization when repo.Name is empty) and deletes it
+func RemoveRuleset(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, rulesetID int64) error {
+	return newRulesetAPI(g, repo, false).remove(ctx, rulesetID, "delete")
+}
+
+// RemoveEnterpriseRuleset backs up a ruleset of an enterprise and deletes it
+func RemoveEnterpriseRuleset(ctx context.Context, g *gh.GitHubClient, enterprise string, rulesetID int64) error {
+	return newEnterpriseRulesetAPI(g, enterprise).remove(ctx, rulesetID, "delete")
+}
+
+// remove backs up a ruleset and deletes it
+func (api *rulesetAPI) remove(ctx context.Context, id int64, operation string) error {
+	if err := api.backup(ctx, id, operation); err != nil {
+		return err
+	}
+	return api.delete(ctx, id)
+}
@@ -0,0 +1,184 @@
+package rulekit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// ConfigFile is a ruleset configuration loaded from a file
+type ConfigFile struct {
+	Path string
+	// Document is the 1-based position of the ruleset in a file holding several rulesets, 0 otherwise
+	Document int
+	Config   *gh.RepositoryRulesetConfig
+	// Err is the error reading or parsing the file when it could not be loaded, in which case Config is nil
+	Err error
+}
+
+// String returns the display name of the ruleset file, including the document position if any
+func (f *ConfigFile) String() string {
+	if f.Document > 0 {
+		return fmt.Sprintf("%s#%d", f.Path, f.Document)
+	}
+	return f.Path
+}
+
+// IsConfigFile reports whether the path has a supported ruleset file extension (JSON or YAML)
+func IsConfigFile(path string) bool {
+	return FormatFromPath(path) != ""
+}
+
+// LoadConfigFiles loads every ruleset of a file, or of stdin when path is '-'.
+// A file may hold a single ruleset, a JSON array of rulesets or a multi-document YAML.
+// When td is not nil, files containing template actions are rendered with it before they are parsed.
+func LoadConfigFiles(path string, td *TemplateData) ([]*ConfigFile, error) {
+	data, err := readConfigData(path, td)
+	if err != nil {
+		return nil, err
+	}
+	configs, err := ParseConfigs(data, FormatFromPath(path))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*ConfigFile, 0, len(configs))
+	for i, config := range configs {
+		file := &ConfigFile{Path: path, Config: config}
+		if len(configs) > 1 {
+			file.Document = i + 1
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// LoadConfigDir loads every ruleset configuration file in a directory, sorted by file name.
+// It fails if any of the files cannot be loaded.
+func LoadConfigDir(dir string, td *TemplateData) ([]*ConfigFile, error) {
+	paths, err := configDirPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := loadConfigPaths(paths, td)
+	var errs []error
+	for _, file := range files {
+		if file.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file.Path, file.Err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return files, nil
+}
+
+// LoadConfigInput loads rulesets from stdin ('-'), a directory, a glob pattern or a single file.
+// When the input resolves to several files, the files that cannot be loaded are returned with Err set;
+// it only fails if none of them can be loaded.
+func LoadConfigInput(input string, td *TemplateData) ([]*ConfigFile, error) {
+	paths, err := configInputPaths(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 1 && paths[0] == input {
+		return LoadConfigFiles(input, td)
+	}
+	files := loadConfigPaths(paths, td)
+	var errs []error
+	for _, file := range files {
+		if file.Err == nil {
+			return files, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", file.Path, file.Err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// configInputPaths resolves stdin ('-'), a directory, a glob pattern or a single file to the ruleset files to load
+func configInputPaths(input string) ([]string, error) {
+	if input == "-" {
+		return []string{input}, nil
+	}
+
+	info, err := os.Stat(input)
+	if err == nil && info.IsDir() {
+		paths, err := configDirPaths(input)
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no ruleset files found in %s", input)
+		}
+		return paths, nil
+	}
+	if err != nil && strings.ContainsAny(input, "*?[") {
+		matches, err := filepath.Glob(input)
+		if err != nil {
+			return nil, err
+		}
+		var paths []string
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() && IsConfigFile(match) {
+				paths = append(paths, match)
+			}
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no ruleset files match %s", input)
+		}
+		sort.Strings(paths)
+		return paths, nil
+	}
+	return []string{input}, nil
+}
+
+// configDirPaths returns the ruleset files in a directory, sorted by file name
+func configDirPaths(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && IsConfigFile(entry.Name()) {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// readConfigData reads a ruleset file, or stdin when path is '-', and renders its template actions with td
+func readConfigData(path string, td *TemplateData) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = readStdin()
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return RenderTemplate(path, data, td)
+}
+
+// loadConfigPaths loads the rulesets of each file in path order. A file that cannot be loaded is returned
+// with Err set instead of stopping the others from loading.
+func loadConfigPaths(paths []string, td *TemplateData) []*ConfigFile {
+	sort.Strings(paths)
+	var files []*ConfigFile
+	for _, path := range paths {
+		loaded, err := LoadConfigFiles(path, td)
+		if err != nil {
+			files = append(files, &ConfigFile{Path: path, Err: err})
+			continue
+		}
+		files = append(files, loaded...)
+	}
+	return files
+}
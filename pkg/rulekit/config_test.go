@@ -0,0 +1,68 @@
+package rulekit
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadConfigInputReportsEachFile(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"a.json":     `{"name":"a","target":"branch","enforcement":"active"}`,
+		"b.json":     `{"name":`,
+		"c.yaml":     "name: c\ntarget: branch\nenforcement: active\n---\nname: d\ntarget: tag\nenforcement: disabled\n",
+		"ignore.txt": "not a ruleset",
+	})
+
+	files, err := LoadConfigInput(dir, nil)
+	if err != nil {
+		t.Fatalf("LoadConfigInput() error = %v", err)
+	}
+	var names []string
+	var failed []string
+	for _, file := range files {
+		if file.Err != nil {
+			failed = append(failed, filepath.Base(file.Path))
+			continue
+		}
+		names = append(names, file.Config.Name)
+	}
+	if got, want := names, []string{"a", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("loaded rulesets = %v, want %v", got, want)
+	}
+	if got, want := failed, []string{"b.json"}; !slices.Equal(got, want) {
+		t.Errorf("failed files = %v, want %v", got, want)
+	}
+}
+
+func TestLoadConfigInputFailsWhenNothingLoads(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"a.json": `{"name":`,
+		"b.json": `[`,
+	})
+	if _, err := LoadConfigInput(dir, nil); err == nil {
+		t.Error("LoadConfigInput() error = nil, want an error")
+	}
+}
+
+func TestLoadConfigDirFailsOnAnyFile(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"a.json": `{"name":"a","target":"branch","enforcement":"active"}`,
+		"b.json": `{"name":`,
+	})
+	if _, err := LoadConfigDir(dir, nil); err == nil {
+		t.Error("LoadConfigDir() error = nil, want an error")
+	}
+}
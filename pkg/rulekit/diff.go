@@ -0,0 +1,323 @@
+package rulekit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// ChangeType is the kind of a field-level change
+type ChangeType string
+
+const (
+	ChangeTypeAdded   ChangeType = "added"
+	ChangeTypeRemoved ChangeType = "removed"
+	ChangeTypeChanged ChangeType = "changed"
+)
+
+// Change is a single field-level difference between two rulesets
+type Change struct {
+	Path   string     `json:"path"`
+	Type   ChangeType `json:"type"`
+	Before any        `json:"before,omitempty"`
+	After  any        `json:"after,omitempty"`
+}
+
+// Plan describes what a write operation would change on a single ruleset
+type Plan struct {
+	Action  ApplyAction                 `json:"action"`
+	Name    string                      `json:"name"`
+	Target  string                      `json:"target,omitempty"`
+	Changes []*Change                   `json:"changes"`
+	Before  *gh.RepositoryRulesetConfig `json:"-"`
+	After   *gh.RepositoryRulesetConfig `json:"-"`
+}
+
+// NewPlan compares the current and the desired state of a ruleset.
+// A nil before means the ruleset will be created, a nil after means it will be deleted.
+func NewPlan(target string, before, after *gh.RepositoryRulesetConfig) (*Plan, error) {
+	changes, err := DiffConfig(before, after)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{Target: target, Changes: changes, Before: before, After: after}
+	switch {
+	case before == nil:
+		plan.Action = ApplyActionCreate
+		plan.Name = after.Name
+	case after == nil:
+		plan.Action = ApplyActionDelete
+		plan.Name = before.Name
+	case len(changes) == 0:
+		plan.Action = ApplyActionNoChange
+		plan.Name = after.Name
+	default:
+		plan.Action = ApplyActionUpdate
+		plan.Name = after.Name
+	}
+	return plan, nil
+}
+
+// UnifiedDiff returns the unified diff of the plan in the canonical export format
+func (p *Plan) UnifiedDiff() (string, error) {
+	from, to := "/dev/null", "/dev/null"
+	if p.Before != nil {
+		from = "a/" + p.Name
+	}
+	if p.After != nil {
+		to = "b/" + p.Name
+	}
+	return UnifiedDiff(p.Before, p.After, from, to)
+}
+
+// NewApplyChangePlan builds the plan of a change computed by PlanApply
+func NewApplyChangePlan(target string, change *ApplyChange) (*Plan, error) {
+	var before, after *gh.RepositoryRulesetConfig
+	if change.Current != nil {
+		before = gh.ExportRuleset(change.Current)
+	}
+	if change.Action != ApplyActionDelete {
+		after = change.Desired
+	}
+	return NewPlan(target, before, after)
+}
+
+// DiffConfig returns the field-level differences between two ruleset configs.
+// Rules are matched by type, bypass actors by actor type and ID, and status checks by context,
+// so that reordering alone is not reported as a change.
+func DiffConfig(before, after *gh.RepositoryRulesetConfig) ([]*Change, error) {
+	var a, b any = map[string]any{}, map[string]any{}
+	var err error
+	if before != nil {
+		if a, err = toGeneric(NormalizeConfig(before)); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if b, err = toGeneric(NormalizeConfig(after)); err != nil {
+			return nil, err
+		}
+	}
+	changes := []*Change{}
+	diffValue("", a, b, &changes)
+	return changes, nil
+}
+
+// UnifiedDiff returns a unified diff of two ruleset configs in canonical JSON form
+func UnifiedDiff(before, after *gh.RepositoryRulesetConfig, fromFile, toFile string) (string, error) {
+	a, err := canonicalJSON(before)
+	if err != nil {
+		return "", err
+	}
+	b, err := canonicalJSON(after)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// canonicalJSON renders a normalized config with keyed arrays sorted so that diffs are stable
+func canonicalJSON(config *gh.RepositoryRulesetConfig) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+	generic, err := toGeneric(NormalizeConfig(config))
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(canonicalize(generic), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+func canonicalize(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k] = canonicalize(e)
+		}
+		return t
+	case []any:
+		for i, e := range t {
+			t[i] = canonicalize(e)
+		}
+		if keys, ok := elementKeys(t); ok {
+			order := make([]int, len(t))
+			for i := range order {
+				order[i] = i
+			}
+			sort.SliceStable(order, func(i, j int) bool { return keys[order[i]] < keys[order[j]] })
+			sorted := make([]any, len(t))
+			for i, idx := range order {
+				sorted[i] = t[idx]
+			}
+			return sorted
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// elementKey returns the identity of an array element that is an object, if it has one
+func elementKey(v any) (string, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	if t, ok := m["type"].(string); ok {
+		return t, true
+	}
+	if t, ok := m["actor_type"].(string); ok {
+		if id, ok := m["actor_id"].(float64); ok {
+			return fmt.Sprintf("%s:%d", t, int64(id)), true
+		}
+		return t, true
+	}
+	for _, field := range []string{"context", "tool", "path", "name"} {
+		s, ok := m[field].(string)
+		if !ok {
+			continue
+		}
+		// Status checks of different apps and workflows of different repositories or refs may share a name
+		for _, qualifier := range []string{"integration_id", "repository_id", "ref", "source"} {
+			switch q := m[qualifier].(type) {
+			case float64:
+				s += fmt.Sprintf(":%d", int64(q))
+			case string:
+				s += ":" + q
+			}
+		}
+		return s, true
+	}
+	return "", false
+}
+
+// elementKeys returns the keys of all elements when every element is an identifiable object
+// or a scalar, in which case the scalar value itself is the key
+func elementKeys(list []any) ([]string, bool) {
+	keys := make([]string, 0, len(list))
+	for _, e := range list {
+		if key, ok := elementKey(e); ok {
+			keys = append(keys, key)
+			continue
+		}
+		switch s := e.(type) {
+		case string:
+			keys = append(keys, s)
+		case float64, bool:
+			keys = append(keys, fmt.Sprint(s))
+		default:
+			return nil, false
+		}
+	}
+	return keys, true
+}
+
+// keyedElements returns the elements of a list by key. Lists with elements that cannot be keyed or share a key
+// are not keyed, so that they are compared as a whole instead of losing elements.
+func keyedElements(list []any) (map[string]any, bool) {
+	keys, ok := elementKeys(list)
+	if !ok {
+		return nil, false
+	}
+	elements := make(map[string]any, len(list))
+	for i, key := range keys {
+		if _, ok := elements[key]; ok {
+			return nil, false
+		}
+		elements[key] = list[i]
+	}
+	return elements, true
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func indexPath(path, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		key = fmt.Sprintf("%q", key)
+	}
+	return fmt.Sprintf("%s[%s]", path, key)
+}
+
+func diffValue(path string, a, b any, changes *[]*Change) {
+	if a == nil && b == nil {
+		return
+	}
+	if a == nil {
+		*changes = append(*changes, &Change{Path: path, Type: ChangeTypeAdded, After: b})
+		return
+	}
+	if b == nil {
+		*changes = append(*changes, &Change{Path: path, Type: ChangeTypeRemoved, Before: a})
+		return
+	}
+
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffValue(joinPath(path, k), av[k], bv[k], changes)
+		}
+		return
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		am, aok := keyedElements(av)
+		bm, bok := keyedElements(bv)
+		if !aok || !bok {
+			break
+		}
+		keys := make([]string, 0, len(am)+len(bm))
+		for k := range am {
+			keys = append(keys, k)
+		}
+		for k := range bm {
+			if _, ok := am[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diffValue(indexPath(path, k), am[k], bm[k], changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, &Change{Path: path, Type: ChangeTypeChanged, Before: a, After: b})
+	}
+}
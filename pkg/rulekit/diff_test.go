@@ -0,0 +1,159 @@
+package rulekit
+
+import (
+	"testing"
+)
+
+func TestDiffConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []Change
+	}{
+		{
+			name:   "identical",
+			before: `{"name":"main","target":"branch","enforcement":"active"}`,
+			after:  `{"name":"main","target":"branch","enforcement":"active"}`,
+		},
+		{
+			name:   "changed enforcement",
+			before: `{"name":"main","target":"branch","enforcement":"active"}`,
+			after:  `{"name":"main","target":"branch","enforcement":"evaluate"}`,
+			want:   []Change{{Path: "enforcement", Type: ChangeTypeChanged, Before: "active", After: "evaluate"}},
+		},
+		{
+			name:   "reordered rules",
+			before: `{"name":"main","enforcement":"active","rules":[{"type":"deletion"},{"type":"non_fast_forward"}]}`,
+			after:  `{"name":"main","enforcement":"active","rules":[{"type":"non_fast_forward"},{"type":"deletion"}]}`,
+		},
+		{
+			name:   "added and removed rules",
+			before: `{"name":"main","enforcement":"active","rules":[{"type":"deletion"}]}`,
+			after:  `{"name":"main","enforcement":"active","rules":[{"type":"non_fast_forward"}]}`,
+			want: []Change{
+				{Path: "rules[deletion]", Type: ChangeTypeRemoved},
+				{Path: "rules[non_fast_forward]", Type: ChangeTypeAdded},
+			},
+		},
+		{
+			name:   "changed rule parameter",
+			before: `{"name":"main","enforcement":"active","rules":[{"type":"pull_request","parameters":{"required_approving_review_count":1}}]}`,
+			after:  `{"name":"main","enforcement":"active","rules":[{"type":"pull_request","parameters":{"required_approving_review_count":2}}]}`,
+			want: []Change{
+				{Path: "rules[pull_request].parameters.required_approving_review_count", Type: ChangeTypeChanged, Before: float64(1), After: float64(2)},
+			},
+		},
+		{
+			name:   "bypass actors are keyed by type and ID",
+			before: `{"name":"main","enforcement":"active","bypass_actors":[{"actor_id":5,"actor_type":"RepositoryRole","bypass_mode":"always"}]}`,
+			after:  `{"name":"main","enforcement":"active","bypass_actors":[{"actor_id":7,"actor_type":"Team","bypass_mode":"always"},{"actor_id":5,"actor_type":"RepositoryRole","bypass_mode":"pull_request"}]}`,
+			want: []Change{
+				{Path: "bypass_actors[RepositoryRole:5].bypass_mode", Type: ChangeTypeChanged, Before: "always", After: "pull_request"},
+				{Path: "bypass_actors[Team:7]", Type: ChangeTypeAdded},
+			},
+		},
+		{
+			name:   "keys with dots are quoted",
+			before: `{"name":"main","enforcement":"active","rules":[{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":false,"required_status_checks":[{"context":"ci.build"}]}}]}`,
+			after:  `{"name":"main","enforcement":"active","rules":[{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":false,"required_status_checks":[{"context":"ci.test"}]}}]}`,
+			want: []Change{
+				{Path: `rules[required_status_checks].parameters.required_status_checks["ci.build"]`, Type: ChangeTypeRemoved},
+				{Path: `rules[required_status_checks].parameters.required_status_checks["ci.test"]`, Type: ChangeTypeAdded},
+			},
+		},
+		{
+			name:   "status checks of different apps with the same context",
+			before: `{"name":"main","enforcement":"active","rules":[{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":false,"required_status_checks":[{"context":"ci","integration_id":1},{"context":"ci","integration_id":2}]}}]}`,
+			after:  `{"name":"main","enforcement":"active","rules":[{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":false,"required_status_checks":[{"context":"ci","integration_id":2},{"context":"ci","integration_id":3}]}}]}`,
+			want: []Change{
+				{Path: "rules[required_status_checks].parameters.required_status_checks[ci:1]", Type: ChangeTypeRemoved},
+				{Path: "rules[required_status_checks].parameters.required_status_checks[ci:3]", Type: ChangeTypeAdded},
+			},
+		},
+		{
+			name:   "workflows of different repositories with the same path",
+			before: `{"name":"main","enforcement":"active","rules":[{"type":"workflows","parameters":{"workflows":[{"path":".github/workflows/ci.yml","repository_id":1,"ref":"main"},{"path":".github/workflows/ci.yml","repository_id":2,"ref":"main"}]}}]}`,
+			after:  `{"name":"main","enforcement":"active","rules":[{"type":"workflows","parameters":{"workflows":[{"path":".github/workflows/ci.yml","repository_id":1,"ref":"main"},{"path":".github/workflows/ci.yml","repository_id":2,"ref":"v2"}]}}]}`,
+			want: []Change{
+				{Path: `rules[workflows].parameters.workflows[".github/workflows/ci.yml:2:main"]`, Type: ChangeTypeRemoved},
+				{Path: `rules[workflows].parameters.workflows[".github/workflows/ci.yml:2:v2"]`, Type: ChangeTypeAdded},
+			},
+		},
+		{
+			name:   "elements sharing a key are compared as a whole",
+			before: `{"name":"main","enforcement":"active","rules":[{"type":"code_scanning","parameters":{"code_scanning_tools":[{"tool":"CodeQL","alerts_threshold":"errors","security_alerts_threshold":"all"},{"tool":"CodeQL","alerts_threshold":"all","security_alerts_threshold":"all"}]}}]}`,
+			after:  `{"name":"main","enforcement":"active","rules":[{"type":"code_scanning","parameters":{"code_scanning_tools":[{"tool":"CodeQL","alerts_threshold":"errors","security_alerts_threshold":"all"},{"tool":"CodeQL","alerts_threshold":"none","security_alerts_threshold":"all"}]}}]}`,
+			want: []Change{
+				{Path: "rules[code_scanning].parameters.code_scanning_tools", Type: ChangeTypeChanged},
+			},
+		},
+		{
+			name:   "reordered ref patterns",
+			before: `{"name":"main","enforcement":"active","conditions":{"ref_name":{"include":["refs/heads/main","~DEFAULT_BRANCH"],"exclude":[]}}}`,
+			after:  `{"name":"main","enforcement":"active","conditions":{"ref_name":{"include":["~DEFAULT_BRANCH","refs/heads/main"],"exclude":[]}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := DiffConfig(mustConfig(t, tt.before), mustConfig(t, tt.after))
+			if err != nil {
+				t.Fatalf("DiffConfig() error = %v", err)
+			}
+			if len(changes) != len(tt.want) {
+				t.Fatalf("DiffConfig() = %d changes %v, want %d", len(changes), changePaths(changes), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := changes[i]
+				if got.Path != want.Path || got.Type != want.Type {
+					t.Errorf("change[%d] = %s %s, want %s %s", i, got.Type, got.Path, want.Type, want.Path)
+				}
+				if want.Type == ChangeTypeChanged && want.Before != nil && (got.Before != want.Before || got.After != want.After) {
+					t.Errorf("change[%d] = %v -> %v, want %v -> %v", i, got.Before, got.After, want.Before, want.After)
+				}
+			}
+		})
+	}
+}
+
+func TestDiffConfigCreateAndDelete(t *testing.T) {
+	config := mustConfig(t, `{"name":"main","target":"branch","enforcement":"active"}`)
+	for _, tt := range []struct {
+		name          string
+		before, after bool
+		want          ChangeType
+	}{
+		{name: "create", after: true, want: ChangeTypeAdded},
+		{name: "delete", before: true, want: ChangeTypeRemoved},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var before, after = config, config
+			if !tt.before {
+				before = nil
+			}
+			if !tt.after {
+				after = nil
+			}
+			changes, err := DiffConfig(before, after)
+			if err != nil {
+				t.Fatalf("DiffConfig() error = %v", err)
+			}
+			if len(changes) == 0 {
+				t.Fatal("DiffConfig() returned no changes")
+			}
+			for _, change := range changes {
+				if change.Type != tt.want {
+					t.Errorf("change %s = %s, want %s", change.Path, change.Type, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func changePaths(changes []*Change) []string {
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		paths = append(paths, string(change.Type)+" "+change.Path)
+	}
+	return paths
+}
@@ -0,0 +1,82 @@
+package rulekit
+
+import (
+	"context"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// DriftStatus is the state of a live ruleset compared to its committed file
+type DriftStatus string
+
+const (
+	DriftStatusInSync    DriftStatus = "in-sync"
+	DriftStatusDrifted   DriftStatus = "drifted"
+	DriftStatusMissing   DriftStatus = "missing"
+	DriftStatusUnmanaged DriftStatus = "unmanaged"
+)
+
+// Drift is the comparison result of a single ruleset.
+// Changes are expressed from the committed file (before) to the live ruleset (after).
+type Drift struct {
+	Status  DriftStatus `json:"status"`
+	Name    string      `json:"name"`
+	Path    string      `json:"path,omitempty"`
+	ID      *int64      `json:"id,omitempty"`
+	Changes []*Change   `json:"changes,omitempty"`
+}
+
+// DetectDrift compares committed ruleset files with the live rulesets of a repository or organization
+// (organization when repo.Name is empty). Live rulesets without a file are reported as unmanaged
+// only when includeUnmanaged is true.
+func DetectDrift(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, files []*ConfigFile, includeUnmanaged bool) ([]*Drift, error) {
+	changes, err := PlanApply(ctx, g, repo, files, includeUnmanaged)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := make([]*Drift, 0, len(changes))
+	for _, change := range changes {
+		drift := &Drift{Name: change.Name, Path: change.Path}
+		var expected, actual *gh.RepositoryRulesetConfig
+		if change.Current != nil {
+			drift.ID = change.Current.ID
+			actual = gh.ExportRuleset(change.Current)
+		}
+		if change.Action != ApplyActionDelete {
+			expected = change.Desired
+		}
+		switch change.Action {
+		case ApplyActionCreate:
+			drift.Status = DriftStatusMissing
+		case ApplyActionDelete:
+			drift.Status = DriftStatusUnmanaged
+		case ApplyActionUpdate:
+			// Only field-level differences count as drift, so reordered arrays are in sync
+			drift.Changes, err = DiffConfig(expected, actual)
+			if err != nil {
+				return nil, err
+			}
+			drift.Status = DriftStatusDrifted
+			if len(drift.Changes) == 0 {
+				drift.Status = DriftStatusInSync
+			}
+		default:
+			drift.Status = DriftStatusInSync
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// CountDrift returns the number of rulesets that are not in sync
+func CountDrift(drifts []*Drift) int {
+	count := 0
+	for _, drift := range drifts {
+		if drift.Status != DriftStatusInSync {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,375 @@
+package rulekit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// RulesetEdit changes part of a ruleset in the generic form of its export format
+type RulesetEdit func(ruleset map[string]any) error
+
+// BypassModes lists the bypass modes a bypass actor can be added with
+var BypassModes = []string{
+	string(github.BypassModeAlways),
+	string(github.BypassModePullRequest),
+	string(github.BypassModeExempt),
+}
+
+// Condition lists that patterns can be added to
+const (
+	ConditionRefName        = "ref_name"
+	ConditionRepositoryName = "repository_name"
+)
+
+// PlanRulesetEdit finds a ruleset of a repository or organization (organization when repo.Name is empty) by ID
+// or name, applies edit to it and returns the plan of the change. Edits that leave the ruleset invalid are rejected.
+func PlanRulesetEdit(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, target string, idOrName string, edit RulesetEdit) (*Plan, error) {
+	ruleset, err := newRulesetAPI(g, repo, false).find(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	before := gh.ExportRuleset(ruleset)
+	value, err := toGeneric(before)
+	if err != nil {
+		return nil, err
+	}
+	generic, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ruleset format")
+	}
+	original := ValidateRuleset(before.Name, generic)
+	if err := edit(generic); err != nil {
+		return nil, err
+	}
+	if err := newValidationErrors(original, ValidateRuleset(before.Name, generic)); err != nil {
+		return nil, err
+	}
+	after, err := fromGeneric(generic)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlan(target, before, after)
+}
+
+// ApplyRulesetEdit updates the ruleset of a plan returned by PlanRulesetEdit. The ruleset is backed up first.
+func ApplyRulesetEdit(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, plan *Plan) (*github.RepositoryRuleset, error) {
+	updated, _, err := newRulesetAPI(g, repo, false).importConfigAs(ctx, plan.After, false, "edit")
+	return updated, err
+}
+
+// newValidationErrors returns an error listing the validation errors of after that are not in before, or nil.
+// Problems the live ruleset already has are left alone so that they do not block unrelated edits.
+func newValidationErrors(before, after []*ValidationError) error {
+	known := map[string]bool{}
+	for _, e := range before {
+		known[e.Path+": "+e.Message] = true
+	}
+	var messages []string
+	for _, e := range after {
+		if message := e.Path + ": " + e.Message; !known[message] {
+			messages = append(messages, message)
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid ruleset after edit: %s", strings.Join(messages, "; "))
+}
+
+// fromGeneric converts the generic JSON representation of a ruleset back to the export format
+func fromGeneric(generic map[string]any) (*gh.RepositoryRulesetConfig, error) {
+	data, err := json.Marshal(generic)
+	if err != nil {
+		return nil, err
+	}
+	var config gh.RepositoryRulesetConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// ruleParam is a rule parameter change given as 'name=value', 'name+=value' or 'name-=value'
+type ruleParam struct {
+	name  string
+	op    string
+	value any
+}
+
+// parseRuleParams parses rule parameter changes. Values are parsed as JSON when possible, otherwise taken as strings.
+func parseRuleParams(params []string) ([]*ruleParam, error) {
+	result := make([]*ruleParam, 0, len(params))
+	for _, param := range params {
+		name, raw, ok := strings.Cut(param, "=")
+		if !ok || strings.TrimRight(name, "+-") == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected name=value, name+=value or name-=value", param)
+		}
+		p := &ruleParam{name: name, op: "="}
+		if strings.HasSuffix(name, "+") || strings.HasSuffix(name, "-") {
+			p.name = name[:len(name)-1]
+			p.op = name[len(name)-1:] + "="
+		}
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		p.value = value
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// apply applies the change to rule parameters. '+=' appends values missing from a list, '-=' removes them.
+func (p *ruleParam) apply(parameters map[string]any) error {
+	if p.op == "=" {
+		parameters[p.name] = p.value
+		return nil
+	}
+	current, ok := parameters[p.name].([]any)
+	if !ok && parameters[p.name] != nil {
+		return fmt.Errorf("parameter '%s' is not a list", p.name)
+	}
+	values, ok := p.value.([]any)
+	if !ok {
+		values = []any{p.value}
+	}
+	for _, value := range values {
+		index := slicesIndex(current, value)
+		if p.op == "+=" {
+			if index < 0 {
+				current = append(current, value)
+			}
+			continue
+		}
+		if index < 0 {
+			return fmt.Errorf("parameter '%s' has no value %s", p.name, jsonString(value))
+		}
+		current = append(current[:index], current[index+1:]...)
+	}
+	if current == nil {
+		current = []any{}
+	}
+	parameters[p.name] = current
+	return nil
+}
+
+func slicesIndex(list []any, value any) int {
+	for i, item := range list {
+		if reflect.DeepEqual(item, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func jsonString(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// rules returns the rules of a generic ruleset and the index of the rule of the given type, or -1
+func rules(ruleset map[string]any, ruleType string) ([]any, int) {
+	list, _ := ruleset["rules"].([]any)
+	for i, item := range list {
+		if rule, ok := item.(map[string]any); ok && rule["type"] == ruleType {
+			return list, i
+		}
+	}
+	return list, -1
+}
+
+// AddRule returns an edit that adds a rule of the given type with parameters given as 'name=value'
+func AddRule(ruleType string, params []string) RulesetEdit {
+	return func(ruleset map[string]any) error {
+		parsed, err := parseRuleParams(params)
+		if err != nil {
+			return err
+		}
+		list, index := rules(ruleset, ruleType)
+		if index >= 0 {
+			return fmt.Errorf("rule '%s' already exists in the ruleset, use set-param to change it", ruleType)
+		}
+		rule := map[string]any{"type": ruleType}
+		if len(parsed) > 0 {
+			parameters := map[string]any{}
+			for _, p := range parsed {
+				if err := p.apply(parameters); err != nil {
+					return err
+				}
+			}
+			rule["parameters"] = parameters
+		}
+		ruleset["rules"] = append(list, rule)
+		return nil
+	}
+}
+
+// RemoveRule returns an edit that removes the rule of the given type
+func RemoveRule(ruleType string) RulesetEdit {
+	return func(ruleset map[string]any) error {
+		list, index := rules(ruleset, ruleType)
+		if index < 0 {
+			return fmt.Errorf("rule '%s' not found in the ruleset", ruleType)
+		}
+		ruleset["rules"] = append(list[:index], list[index+1:]...)
+		return nil
+	}
+}
+
+// SetRuleParams returns an edit that changes parameters of the rule of the given type with parameters given as
+// 'name=value' to set a value, 'name+=value' to add values to a list or 'name-=value' to remove values from a list
+func SetRuleParams(ruleType string, params []string) RulesetEdit {
+	return func(ruleset map[string]any) error {
+		parsed, err := parseRuleParams(params)
+		if err != nil {
+			return err
+		}
+		if len(parsed) == 0 {
+			return fmt.Errorf("no parameters given")
+		}
+		list, index := rules(ruleset, ruleType)
+		if index < 0 {
+			return fmt.Errorf("rule '%s' not found in the ruleset, use add to add it", ruleType)
+		}
+		rule := list[index].(map[string]any)
+		parameters, _ := rule["parameters"].(map[string]any)
+		if parameters == nil {
+			parameters = map[string]any{}
+		}
+		for _, p := range parsed {
+			if err := p.apply(parameters); err != nil {
+				return err
+			}
+		}
+		rule["parameters"] = parameters
+		return nil
+	}
+}
+
+// BypassActorSpec is a bypass actor given on the command line. Exactly one field is expected to be set; Team, App
+// and Role take an ID or a name: a team slug, an app slug, or a repository role name such as 'maintain'.
+type BypassActorSpec struct {
+	Team              string
+	App               string
+	Role              string
+	OrganizationAdmin bool
+	DeployKey         bool
+}
+
+// Resolve returns the type and the ID of the bypass actor in a repository or organization. The ID is nil for actors
+// without one, such as organization admins and deploy keys.
+func (s *BypassActorSpec) Resolve(ctx context.Context, g *gh.GitHubClient, repo repository.Repository) (github.BypassActorType, *int64, error) {
+	var actorType github.BypassActorType
+	var value string
+	switch {
+	case s.Team != "":
+		actorType, value = github.BypassActorTypeTeam, s.Team
+	case s.App != "":
+		actorType, value = github.BypassActorTypeIntegration, s.App
+	case s.Role != "":
+		actorType, value = github.BypassActorTypeRepositoryRole, s.Role
+	case s.OrganizationAdmin:
+		return github.BypassActorTypeOrganizationAdmin, nil, nil
+	case s.DeployKey:
+		return github.BypassActorTypeDeployKey, nil, nil
+	default:
+		return "", nil, fmt.Errorf("no bypass actor given")
+	}
+	id, err := NewActorResolver(nil, g, repo, g, repo).resolveDestination(ctx, string(actorType), value)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve %s '%s': %w", actorType, value, err)
+	}
+	return actorType, &id, nil
+}
+
+// bypassActorIndex returns the index of the bypass actor of the given type and ID, where a nil ID matches by type only
+func bypassActorIndex(actors []any, actorType github.BypassActorType, actorID *int64) int {
+	for i, item := range actors {
+		actor, ok := item.(map[string]any)
+		if !ok || actor["actor_type"] != string(actorType) {
+			continue
+		}
+		if actorID == nil {
+			return i
+		}
+		if id, ok := actor["actor_id"].(float64); ok && int64(id) == *actorID {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddBypassActor returns an edit that adds a bypass actor, or changes the bypass mode of the actor when it exists.
+// actorID is nil for actors without an ID, such as OrganizationAdmin and DeployKey.
+func AddBypassActor(actorType github.BypassActorType, actorID *int64, mode string) RulesetEdit {
+	return func(ruleset map[string]any) error {
+		actors, _ := ruleset["bypass_actors"].([]any)
+		if index := bypassActorIndex(actors, actorType, actorID); index >= 0 {
+			actors[index].(map[string]any)["bypass_mode"] = mode
+			return nil
+		}
+		actor := map[string]any{"actor_type": string(actorType), "bypass_mode": mode}
+		if actorID != nil {
+			actor["actor_id"] = *actorID
+		}
+		ruleset["bypass_actors"] = append(actors, actor)
+		return nil
+	}
+}
+
+// RemoveBypassActor returns an edit that removes a bypass actor. actorID is nil for actors without an ID.
+func RemoveBypassActor(actorType github.BypassActorType, actorID *int64) RulesetEdit {
+	return func(ruleset map[string]any) error {
+		actors, _ := ruleset["bypass_actors"].([]any)
+		index := bypassActorIndex(actors, actorType, actorID)
+		if index < 0 {
+			return fmt.Errorf("bypass actor not found in the ruleset")
+		}
+		ruleset["bypass_actors"] = append(actors[:index], actors[index+1:]...)
+		return nil
+	}
+}
+
+// EditConditionPatterns returns an edit that adds patterns to, or removes them from when remove is set, the include
+// or exclude list of a name condition such as ConditionRefName
+func EditConditionPatterns(condition string, list string, patterns []string, remove bool) RulesetEdit {
+	return func(ruleset map[string]any) error {
+		conditions, _ := ruleset["conditions"].(map[string]any)
+		if conditions == nil {
+			conditions = map[string]any{}
+			ruleset["conditions"] = conditions
+		}
+		names, _ := conditions[condition].(map[string]any)
+		if names == nil {
+			names = map[string]any{"include": []any{}, "exclude": []any{}}
+			conditions[condition] = names
+		}
+		values, _ := names[list].([]any)
+		for _, pattern := range patterns {
+			index := slicesIndex(values, pattern)
+			switch {
+			case remove && index < 0:
+				return fmt.Errorf("pattern '%s' not found in %s %s", pattern, condition, list)
+			case remove:
+				values = append(values[:index], values[index+1:]...)
+			case index < 0:
+				values = append(values, pattern)
+			}
+		}
+		if values == nil {
+			values = []any{}
+		}
+		names[list] = values
+		return nil
+	}
+}
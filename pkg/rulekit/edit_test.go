@@ -0,0 +1,151 @@
+package rulekit
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseRuleParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []string
+		want    []*ruleParam
+		wantErr bool
+	}{
+		{
+			name:   "JSON values",
+			params: []string{"count=2", "strict=true", `paths=["a","b"]`},
+			want: []*ruleParam{
+				{name: "count", op: "=", value: float64(2)},
+				{name: "strict", op: "=", value: true},
+				{name: "paths", op: "=", value: []any{"a", "b"}},
+			},
+		},
+		{
+			name:   "non-JSON values are strings",
+			params: []string{"pattern=^feat: ", "operator=starts_with", "empty="},
+			want: []*ruleParam{
+				{name: "pattern", op: "=", value: "^feat: "},
+				{name: "operator", op: "=", value: "starts_with"},
+				{name: "empty", op: "=", value: ""},
+			},
+		},
+		{
+			name:   "list operators",
+			params: []string{"paths+=secrets/**", "paths-=tmp/*", "count=-1"},
+			want: []*ruleParam{
+				{name: "paths", op: "+=", value: "secrets/**"},
+				{name: "paths", op: "-=", value: "tmp/*"},
+				{name: "count", op: "=", value: float64(-1)},
+			},
+		},
+		{
+			name:    "missing value separator",
+			params:  []string{"count"},
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			params:  []string{"+=value"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRuleParams(tt.params)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRuleParams() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRuleParams() = %s, want %s", jsonRuleParams(got), jsonRuleParams(tt.want))
+			}
+		})
+	}
+}
+
+func TestRuleParamApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters string
+		param      string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "set replaces the value",
+			parameters: `{"count":1}`,
+			param:      "count=2",
+			want:       `{"count":2}`,
+		},
+		{
+			name:       "add appends missing values",
+			parameters: `{"paths":["a"]}`,
+			param:      `paths+=["a","b"]`,
+			want:       `{"paths":["a","b"]}`,
+		},
+		{
+			name:       "add creates the list",
+			parameters: `{}`,
+			param:      "paths+=a",
+			want:       `{"paths":["a"]}`,
+		},
+		{
+			name:       "remove drops values",
+			parameters: `{"paths":["a","b","c"]}`,
+			param:      `paths-=["a","c"]`,
+			want:       `{"paths":["b"]}`,
+		},
+		{
+			name:       "remove the last value leaves an empty list",
+			parameters: `{"paths":["a"]}`,
+			param:      "paths-=a",
+			want:       `{"paths":[]}`,
+		},
+		{
+			name:       "remove a missing value",
+			parameters: `{"paths":["a"]}`,
+			param:      "paths-=b",
+			wantErr:    true,
+		},
+		{
+			name:       "add to a non-list",
+			parameters: `{"count":1}`,
+			param:      "count+=2",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parameters map[string]any
+			if err := json.Unmarshal([]byte(tt.parameters), &parameters); err != nil {
+				t.Fatalf("failed to parse parameters: %v", err)
+			}
+			params, err := parseRuleParams([]string{tt.param})
+			if err != nil {
+				t.Fatalf("parseRuleParams() error = %v", err)
+			}
+			err = params[0].apply(parameters)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := jsonString(parameters); got != tt.want {
+				t.Errorf("apply() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func jsonRuleParams(params []*ruleParam) string {
+	list := make([]any, 0, len(params))
+	for _, p := range params {
+		list = append(list, []any{p.name, p.op, p.value})
+	}
+	return jsonString(list)
+}
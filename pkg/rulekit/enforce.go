@@ -0,0 +1,152 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// RulesetEnforcements lists the enforcement values a ruleset can be switched to
+var RulesetEnforcements = []string{
+	string(github.RulesetEnforcementActive),
+	string(github.RulesetEnforcementEvaluate),
+	string(github.RulesetEnforcementDisabled),
+}
+
+// RulesetSelector selects the rulesets given by ID or name, plus the rulesets matching every given filter
+type RulesetSelector struct {
+	Rulesets    []string
+	NamePattern string
+	Enforcement string
+	Target      string
+}
+
+// IsEmpty reports whether the selector selects nothing
+func (s *RulesetSelector) IsEmpty() bool {
+	return len(s.Rulesets) == 0 && s.NamePattern == "" && s.Enforcement == "" && s.Target == ""
+}
+
+// EnforcementChange is the switch of the enforcement of a single ruleset. Previous is kept so the change can be undone.
+type EnforcementChange struct {
+	RulesetID   int64       `json:"ruleset_id"`
+	Name        string      `json:"name"`
+	Previous    string      `json:"previous"`
+	Enforcement string      `json:"enforcement"`
+	Action      ApplyAction `json:"action"`
+}
+
+// PlanEnforcement returns the enforcement changes of the rulesets of a repository or organization (organization
+// when repo.Name is empty) selected by selector: the rulesets given by ID or name first, then the rulesets matching
+// the filters sorted by name. Rulesets that already have the enforcement are marked as no-change.
+func PlanEnforcement(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, selector *RulesetSelector, enforcement string) ([]*EnforcementChange, error) {
+	if !slices.Contains(RulesetEnforcements, enforcement) {
+		return nil, fmt.Errorf("invalid enforcement '%s', expected one of %v", enforcement, RulesetEnforcements)
+	}
+	if selector.IsEmpty() {
+		return nil, fmt.Errorf("no rulesets selected")
+	}
+	api := newRulesetAPI(g, repo, false)
+
+	var selected []*github.RepositoryRuleset
+	for _, idOrName := range selector.Rulesets {
+		ruleset, err := api.find(ctx, idOrName)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, ruleset)
+	}
+	if selector.NamePattern != "" || selector.Enforcement != "" || selector.Target != "" {
+		matched, err := api.filter(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, matched...)
+	}
+
+	seen := map[int64]bool{}
+	var changes []*EnforcementChange
+	for _, ruleset := range selected {
+		if seen[ruleset.GetID()] {
+			continue
+		}
+		seen[ruleset.GetID()] = true
+		action := ApplyActionUpdate
+		if string(ruleset.Enforcement) == enforcement {
+			action = ApplyActionNoChange
+		}
+		changes = append(changes, &EnforcementChange{
+			RulesetID:   ruleset.GetID(),
+			Name:        ruleset.Name,
+			Previous:    string(ruleset.Enforcement),
+			Enforcement: enforcement,
+			Action:      action,
+		})
+	}
+	return changes, nil
+}
+
+// filter returns the rulesets matching the name pattern, enforcement and target of selector, sorted by name
+func (api *rulesetAPI) filter(ctx context.Context, selector *RulesetSelector) ([]*github.RepositoryRuleset, error) {
+	var pattern *regexp.Regexp
+	if selector.NamePattern != "" {
+		var err error
+		pattern, err = fnmatchRegexp(selector.NamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("malformed name pattern '%s'", selector.NamePattern)
+		}
+	}
+	rulesets, err := api.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rulesets: %w", err)
+	}
+	var matched []*github.RepositoryRuleset
+	for _, ruleset := range rulesets {
+		if pattern != nil && !pattern.MatchString(ruleset.Name) {
+			continue
+		}
+		if selector.Enforcement != "" && string(ruleset.Enforcement) != selector.Enforcement {
+			continue
+		}
+		if selector.Target != "" && (ruleset.Target == nil || string(*ruleset.Target) != selector.Target) {
+			continue
+		}
+		matched = append(matched, ruleset)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Name < matched[j].Name
+	})
+	return matched, nil
+}
+
+// ApplyEnforcement switches the enforcement of the rulesets of the changes to update, backing each ruleset up first,
+// and returns the changes that were applied. It stops at the first failure; the changes returned with the error have
+// been applied, and their previous enforcement tells how to undo them.
+func ApplyEnforcement(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, changes []*EnforcementChange) ([]*EnforcementChange, error) {
+	api := newRulesetAPI(g, repo, false)
+	var applied []*EnforcementChange
+	for _, change := range changes {
+		if change.Action != ApplyActionUpdate {
+			continue
+		}
+		if _, err := api.setEnforcement(ctx, change.RulesetID, change.Enforcement, "enforce"); err != nil {
+			return applied, fmt.Errorf("failed to update ruleset '%s': %w", change.Name, err)
+		}
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
+
+// setEnforcement backs up a ruleset and updates only its enforcement, leaving its rules, conditions and bypass actors
+// as they are on GitHub
+func (api *rulesetAPI) setEnforcement(ctx context.Context, id int64, enforcement string, operation string) (*github.RepositoryRuleset, error) {
+	if err := api.backup(ctx, id, operation); err != nil {
+		return nil, err
+	}
+	return api.updateFields(ctx, id, map[string]any{"enforcement": enforcement})
+}
@@ -0,0 +1,48 @@
+package rulekit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/client"
+)
+
+func TestSetEnforcementSendsOnlyEnforcement(t *testing.T) {
+	t.Setenv(BackupDirEnv, t.TempDir())
+
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/rulesets/1" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPut {
+			data, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(data, &body); err != nil {
+				t.Errorf("invalid request body: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":1,"name":"main","enforcement":"evaluate","rules":[{"type":"deletion"}]}`)
+	}))
+	defer server.Close()
+
+	c := github.NewClient(nil)
+	c.BaseURL, _ = url.Parse(server.URL + "/")
+	g, _ := client.NewClient(c)
+	api := newRulesetAPI(g, repository.Repository{Host: "github.com", Owner: "owner", Name: "repo"}, false)
+
+	if _, err := api.setEnforcement(context.Background(), 1, "evaluate", "enforce"); err != nil {
+		t.Fatalf("setEnforcement() error = %v", err)
+	}
+	if len(body) != 1 || body["enforcement"] != "evaluate" {
+		t.Errorf("update body = %v, want only enforcement", body)
+	}
+}
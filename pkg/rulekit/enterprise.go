@@ -0,0 +1,218 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// newEnterpriseRulesetAPI returns the ruleset operations of an enterprise
+func newEnterpriseRulesetAPI(g *gh.GitHubClient, enterprise string) *rulesetAPI {
+	return &rulesetAPI{
+		list: func(ctx context.Context) ([]*github.RepositoryRuleset, error) {
+			return ListEnterpriseRulesets(ctx, g, enterprise)
+		},
+		get: func(ctx context.Context, id int64) (*github.RepositoryRuleset, error) {
+			return GetEnterpriseRuleset(ctx, g, enterprise, id)
+		},
+		create: func(ctx context.Context, ruleset *github.RepositoryRuleset) (*github.RepositoryRuleset, error) {
+			created, _, err := g.GetClient().Enterprise.CreateRepositoryRuleset(ctx, enterprise, *ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create ruleset '%s' of enterprise %s: %w", ruleset.Name, enterprise, err)
+			}
+			return created, nil
+		},
+		update: func(ctx context.Context, id int64, ruleset *github.RepositoryRuleset) (*github.RepositoryRuleset, error) {
+			updated, _, err := g.GetClient().Enterprise.UpdateRepositoryRuleset(ctx, enterprise, id, *ruleset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update ruleset %d of enterprise %s: %w", id, enterprise, err)
+			}
+			return updated, nil
+		},
+		delete: func(ctx context.Context, id int64) error {
+			return DeleteEnterpriseRuleset(ctx, g, enterprise, id)
+		},
+		path: func(id int64) string {
+			return fmt.Sprintf("enterprises/%s/rulesets/%d", enterprise, id)
+		},
+		target: snapshotTarget{scope: SnapshotScopeEnterprise, host: clientHost(g), owner: enterprise},
+		client: g,
+	}
+}
+
+// ListEnterpriseRulesets retrieves all rulesets of an enterprise
+func ListEnterpriseRulesets(ctx context.Context, g *gh.GitHubClient, enterprise string) ([]*github.RepositoryRuleset, error) {
+	client := g.GetClient()
+	var all []*github.RepositoryRuleset
+	page := 1
+	for {
+		u := fmt.Sprintf("enterprises/%s/rulesets?per_page=100&page=%d", enterprise, page)
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		var rulesets []*github.RepositoryRuleset
+		resp, err := client.Do(ctx, req, &rulesets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list rulesets of enterprise %s: %w", enterprise, err)
+		}
+		all = append(all, rulesets...)
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	return all, nil
+}
+
+// GetEnterpriseRuleset retrieves a single ruleset of an enterprise by ruleset ID
+func GetEnterpriseRuleset(ctx context.Context, g *gh.GitHubClient, enterprise string, rulesetID int64) (*github.RepositoryRuleset, error) {
+	ruleset, _, err := g.GetClient().Enterprise.GetRepositoryRuleset(ctx, enterprise, rulesetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ruleset %d of enterprise %s: %w", rulesetID, enterprise, err)
+	}
+	return ruleset, nil
+}
+
+// DeleteEnterpriseRuleset deletes a ruleset of an enterprise
+func DeleteEnterpriseRuleset(ctx context.Context, g *gh.GitHubClient, enterprise string, rulesetID int64) error {
+	if _, err := g.GetClient().Enterprise.DeleteRepositoryRuleset(ctx, enterprise, rulesetID); err != nil {
+		return fmt.Errorf("failed to delete ruleset %d of enterprise %s: %w", rulesetID, enterprise, err)
+	}
+	return nil
+}
+
+// FindEnterpriseRuleset finds an enterprise ruleset by ID or name and returns the full ruleset
+func FindEnterpriseRuleset(ctx context.Context, g *gh.GitHubClient, enterprise string, idOrName string) (*github.RepositoryRuleset, error) {
+	return newEnterpriseRulesetAPI(g, enterprise).find(ctx, idOrName)
+}
+
+// FindEnterpriseRulesetByName returns the enterprise ruleset with the given name, or nil when none matches
+func FindEnterpriseRulesetByName(ctx context.Context, g *gh.GitHubClient, enterprise string, name string) (*github.RepositoryRuleset, error) {
+	return newEnterpriseRulesetAPI(g, enterprise).findByName(ctx, name)
+}
+
+// PlanEnterpriseImport computes the plan of importing a ruleset configuration into an enterprise
+func PlanEnterpriseImport(ctx context.Context, g *gh.GitHubClient, enterprise string, config *gh.RepositoryRulesetConfig, createIfNotExists bool) (*Plan, error) {
+	return newEnterpriseRulesetAPI(g, enterprise).planImport(ctx, enterprise, config, createIfNotExists)
+}
+
+// ImportEnterpriseConfig updates the enterprise ruleset matching a ruleset configuration, or creates it when none matches
+// and createIfNotExists is true
+func ImportEnterpriseConfig(ctx context.Context, g *gh.GitHubClient, enterprise string, config *gh.RepositoryRulesetConfig, createIfNotExists bool) (*github.RepositoryRuleset, ApplyAction, error) {
+	return newEnterpriseRulesetAPI(g, enterprise).importConfig(ctx, config, createIfNotExists)
+}
+
+// ExportEnterpriseRulesets writes every ruleset of an enterprise to dir, one file per ruleset
+func ExportEnterpriseRulesets(ctx context.Context, g *gh.GitHubClient, enterprise string, dir string, format Format) ([]string, error) {
+	return newEnterpriseRulesetAPI(g, enterprise).exportAll(ctx, dir, format)
+}
+
+// NewEnterpriseActorResolver creates a resolver that maps actors from the source enterprise to the destination
+// enterprise. Teams and custom repository roles belong to organizations, so they can only be mapped by ID.
+func NewEnterpriseActorResolver(actorMap *ActorMap, src *gh.GitHubClient, srcEnterprise repository.Repository, dst *gh.GitHubClient, dstEnterprise repository.Repository) *ActorResolver {
+	r := NewActorResolver(actorMap, src, srcEnterprise, dst, dstEnterprise)
+	r.enterprise = true
+	return r
+}
+
+// MapEnterpriseRuleset rewrites the bypass actors and the organization ID conditions of an enterprise ruleset for
+// the destination enterprise. Actors are resolved like in MapMigrateConfig, and organizations are matched by login.
+// It fails when an actor or an organization cannot be resolved, rather than keeping IDs of the source.
+func (r *ActorResolver) MapEnterpriseRuleset(ctx context.Context, ruleset *github.RepositoryRuleset) error {
+	config := &gh.RepositoryRulesetMigrateConfig{
+		Ruleset:   ruleset,
+		Teams:     map[int64]*github.Team{},
+		CheckRuns: map[int64]*gh.CheckRun{},
+	}
+	if _, err := r.MapMigrateConfig(ctx, config); err != nil {
+		return err
+	}
+	if ruleset.Conditions == nil || ruleset.Conditions.OrganizationID == nil {
+		return nil
+	}
+	ids := make([]int64, 0, len(ruleset.Conditions.OrganizationID.OrganizationIDs))
+	for _, id := range ruleset.Conditions.OrganizationID.OrganizationIDs {
+		org, _, err := r.src.GetClient().Organizations.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get organization %d of the source: %w", id, err)
+		}
+		dstOrg, _, err := r.dst.GetClient().Organizations.Get(ctx, org.GetLogin())
+		if err != nil {
+			return fmt.Errorf("failed to get organization '%s' of the destination: %w", org.GetLogin(), err)
+		}
+		ids = append(ids, dstOrg.GetID())
+	}
+	ruleset.Conditions.OrganizationID.OrganizationIDs = ids
+	return nil
+}
+
+// inheritedRuleset is an enterprise ruleset found through one of the repositories it applies to
+type inheritedRuleset struct {
+	ruleset    *github.RepositoryRuleset
+	repository repository.Repository
+}
+
+// listInheritedRulesets collects the enterprise rulesets that apply to at least one repository of an organization.
+// The organization API does not list enterprise rulesets, so every repository is listed with its parent rulesets.
+func listInheritedRulesets(ctx context.Context, g *gh.GitHubClient, org repository.Repository) ([]*inheritedRuleset, error) {
+	repos, err := QueryRepositories(ctx, g, org, &RepositoryQuery{})
+	if err != nil {
+		return nil, err
+	}
+	seen := map[int64]bool{}
+	var inherited []*inheritedRuleset
+	for _, repo := range repos {
+		rulesets, err := gh.ListRepositoryRulesets(ctx, g, repo, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list rulesets of %s: %w", repo.Name, err)
+		}
+		for _, ruleset := range rulesets {
+			if ruleset.SourceType == nil || *ruleset.SourceType != github.RulesetSourceTypeEnterprise || seen[ruleset.GetID()] {
+				continue
+			}
+			seen[ruleset.GetID()] = true
+			inherited = append(inherited, &inheritedRuleset{ruleset: ruleset, repository: repo})
+		}
+	}
+	return inherited, nil
+}
+
+// ListOrgRulesets returns the rulesets of an organization. When includesParents is true, they are followed by
+// the enterprise rulesets that apply to at least one of its repositories, which requires one request per repository.
+func ListOrgRulesets(ctx context.Context, g *gh.GitHubClient, org repository.Repository, includesParents bool) ([]*github.RepositoryRuleset, error) {
+	rulesets, err := gh.ListOrgRulesets(ctx, g, org)
+	if err != nil || !includesParents {
+		return rulesets, err
+	}
+	inherited, err := listInheritedRulesets(ctx, g, org)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range inherited {
+		rulesets = append(rulesets, i.ruleset)
+	}
+	return rulesets, nil
+}
+
+// GetOrgRuleset retrieves an organization ruleset by ID. When includesParents is true, it falls back to
+// the enterprise rulesets that apply to the repositories of the organization.
+func GetOrgRuleset(ctx context.Context, g *gh.GitHubClient, org repository.Repository, rulesetID int64, includesParents bool) (*github.RepositoryRuleset, error) {
+	ruleset, err := gh.GetOrgRuleset(ctx, g, org, rulesetID)
+	if err == nil || !includesParents {
+		return ruleset, err
+	}
+	inherited, listErr := listInheritedRulesets(ctx, g, org)
+	if listErr != nil {
+		return nil, listErr
+	}
+	for _, i := range inherited {
+		if i.ruleset.GetID() == rulesetID {
+			return gh.GetRepositoryRuleset(ctx, g, i.repository, rulesetID, true)
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,118 @@
+package rulekit
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+)
+
+func TestMapEnterpriseRuleset(t *testing.T) {
+	team := github.BypassActorTypeTeam
+	admin := github.BypassActorTypeOrganizationAdmin
+	tests := []struct {
+		name     string
+		actorMap *ActorMap
+		wantIDs  []int64
+		wantErr  bool
+	}{
+		{
+			name:    "unmapped team is refused",
+			wantErr: true,
+		},
+		{
+			name:     "mapped team gets the destination ID",
+			actorMap: &ActorMap{Actors: []*ActorMapping{{Type: "Team", Source: "10", Destination: "20"}}},
+			wantIDs:  []int64{1, 20},
+		},
+		{
+			name:     "team mapped to none is dropped",
+			actorMap: &ActorMap{Actors: []*ActorMapping{{Type: "Team", Source: "10", Destination: ActorNone}}},
+			wantIDs:  []int64{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleset := &github.RepositoryRuleset{
+				Name: "main",
+				BypassActors: []*github.BypassActor{
+					{ActorID: github.Ptr(int64(1)), ActorType: &admin},
+					{ActorID: github.Ptr(int64(10)), ActorType: &team},
+				},
+			}
+			resolver := NewEnterpriseActorResolver(tt.actorMap, nil, repository.Repository{Owner: "src"}, nil, repository.Repository{Owner: "dst"})
+			err := resolver.MapEnterpriseRuleset(context.Background(), ruleset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MapEnterpriseRuleset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var ids []int64
+			for _, actor := range ruleset.BypassActors {
+				ids = append(ids, actor.GetActorID())
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("actor IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestMapEnterpriseRulesetCustomRoles(t *testing.T) {
+	role := github.BypassActorTypeRepositoryRole
+	tests := []struct {
+		name     string
+		actorMap *ActorMap
+		wantIDs  []int64
+		wantErr  string
+	}{
+		{
+			name:    "unmapped custom role is refused",
+			wantErr: "map the role by ID",
+		},
+		{
+			name:     "custom role mapped by ID",
+			actorMap: &ActorMap{Actors: []*ActorMapping{{Type: "RepositoryRole", Source: "100", Destination: "200"}}},
+			wantIDs:  []int64{5, 200},
+		},
+		{
+			name:     "custom role mapped by name is refused",
+			actorMap: &ActorMap{Actors: []*ActorMapping{{Type: "RepositoryRole", Source: "100", Destination: "reviewer"}}},
+			wantErr:  "cannot be resolved by name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleset := &github.RepositoryRuleset{
+				Name: "main",
+				BypassActors: []*github.BypassActor{
+					{ActorID: github.Ptr(int64(5)), ActorType: &role},
+					{ActorID: github.Ptr(int64(100)), ActorType: &role},
+				},
+			}
+			// No clients are given, as custom roles of enterprises must not be looked up through the organization API
+			resolver := NewEnterpriseActorResolver(tt.actorMap, nil, repository.Repository{Owner: "src"}, nil, repository.Repository{Owner: "dst"})
+			err := resolver.MapEnterpriseRuleset(context.Background(), ruleset)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("MapEnterpriseRuleset() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MapEnterpriseRuleset() error = %v", err)
+			}
+			var ids []int64
+			for _, actor := range ruleset.BypassActors {
+				ids = append(ids, actor.GetActorID())
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("actor IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
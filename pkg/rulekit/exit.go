@@ -0,0 +1,33 @@
+package rulekit
+
+import "fmt"
+
+// Exit codes of commands that report a result through the process exit code. The results share a code because no
+// command reports more than one of them; 1 is left for failures.
+const (
+	// ExitCodeDrifted is used when live rulesets have drifted from their files
+	ExitCodeDrifted = 2
+	// ExitCodeBlocked is used when a simulated event would be blocked
+	ExitCodeBlocked = 2
+)
+
+// ExitCodeError is returned by commands that finish with a result to report through a specific process exit code,
+// such as drift or a blocked simulation, rather than with a failure. It is turned into the exit code once the
+// command has returned and all of its output has been written.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+// NewExitCodeError returns an ExitCodeError with a formatted message
+func NewExitCodeError(code int, format string, args ...any) *ExitCodeError {
+	return &ExitCodeError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,99 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// Extension returns the file extension of the format, including the leading dot
+func (f Format) Extension() string {
+	if f == FormatYAML {
+		return ".yaml"
+	}
+	return ".json"
+}
+
+// Slugify converts a ruleset name to a file name friendly slug.
+// Runs of characters other than ASCII letters and digits are replaced with a single '-'.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// ExportFileNames returns a stable file name for each ruleset, keyed by ruleset ID.
+// Names are slugs of the ruleset name; rulesets whose slugs collide or are empty get their ID appended.
+func ExportFileNames(rulesets []*github.RepositoryRuleset, format Format) map[int64]string {
+	counts := map[string]int{}
+	for _, ruleset := range rulesets {
+		counts[Slugify(ruleset.Name)]++
+	}
+	names := make(map[int64]string, len(rulesets))
+	for _, ruleset := range rulesets {
+		slug := Slugify(ruleset.Name)
+		switch {
+		case slug == "":
+			slug = fmt.Sprintf("ruleset-%d", ruleset.GetID())
+		case counts[slug] > 1:
+			slug = fmt.Sprintf("%s-%d", slug, ruleset.GetID())
+		}
+		names[ruleset.GetID()] = slug + format.Extension()
+	}
+	return names
+}
+
+// ExportRulesets writes every ruleset of a repository or organization (organization when repo.Name is empty)
+// to dir, one file per ruleset, and returns the written paths sorted by name.
+// The directory is only created when there is at least one ruleset.
+func ExportRulesets(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, dir string, format Format) ([]string, error) {
+	return newRulesetAPI(g, repo, false).exportAll(ctx, dir, format)
+}
+
+func (api *rulesetAPI) exportAll(ctx context.Context, dir string, format Format) ([]string, error) {
+	rulesets, err := api.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rulesets: %w", err)
+	}
+	if len(rulesets) == 0 {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	names := ExportFileNames(rulesets, format)
+	paths := make([]string, 0, len(rulesets))
+	for _, summary := range rulesets {
+		// The list API does not return rules and conditions, so fetch each ruleset
+		ruleset, err := api.get(ctx, summary.GetID())
+		if err != nil {
+			return paths, fmt.Errorf("failed to get ruleset '%s': %w", summary.Name, err)
+		}
+		path := filepath.Join(dir, names[summary.GetID()])
+		if err := WriteConfigFile(path, gh.ExportRuleset(ruleset), format); err != nil {
+			return paths, fmt.Errorf("failed to write ruleset '%s': %w", summary.Name, err)
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
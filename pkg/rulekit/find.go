@@ -0,0 +1,15 @@
+package rulekit
+
+import (
+	"context"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// FindRuleset finds a ruleset of a repository or organization (organization when repo.Name is empty)
+// by ID or name and returns the full ruleset including rules and conditions
+func FindRuleset(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, idOrName string) (*github.RepositoryRuleset, error) {
+	return newRulesetAPI(g, repo, false).find(ctx, idOrName)
+}
@@ -0,0 +1,352 @@
+package rulekit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the serialization format of a ruleset file
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Formats is the list of supported ruleset file formats, for use with flags
+var Formats = []string{string(FormatJSON), string(FormatYAML)}
+
+// FormatFromPath returns the format implied by the file extension.
+// It returns an empty Format when the extension is not a known ruleset file extension.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return ""
+	}
+}
+
+// ResolveFormat returns the explicitly requested format, or the one implied by the path.
+// It falls back to JSON when neither is known.
+func ResolveFormat(format string, path string) Format {
+	if format != "" {
+		return Format(format)
+	}
+	if f := FormatFromPath(path); f != "" {
+		return f
+	}
+	return FormatJSON
+}
+
+// DetectFormat guesses the format of ruleset data from its content
+func DetectFormat(data []byte) Format {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// ParseConfig parses a single ruleset in the given format. The format is detected from the content when empty.
+func ParseConfig(data []byte, format Format) (*gh.RepositoryRulesetConfig, error) {
+	configs, err := ParseConfigs(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(configs) != 1 {
+		return nil, fmt.Errorf("expected a single ruleset, found %d", len(configs))
+	}
+	return configs[0], nil
+}
+
+// ParseConfigs parses one or more rulesets in the given format. The format is detected from the content when empty.
+// JSON data may hold a single ruleset or an array of rulesets, and YAML data may hold several documents,
+// each of them a single ruleset or a list of rulesets.
+func ParseConfigs(data []byte, format Format) ([]*gh.RepositoryRulesetConfig, error) {
+	items, err := parseGenericRulesets(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	var configs []*gh.RepositoryRulesetConfig
+	for _, item := range items {
+		config, err := configFromGeneric(item)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	if len(configs) == 0 {
+		return nil, errors.New("no ruleset found")
+	}
+	return configs, nil
+}
+
+// parseGenericRulesets parses every ruleset of the data into generic values, flattening arrays and lists.
+// The format is detected from the content when empty.
+func parseGenericRulesets(data []byte, format Format) ([]any, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+	documents, err := parseGenericDocuments(data, format)
+	if err != nil {
+		return nil, err
+	}
+	var items []any
+	for _, document := range documents {
+		if list, ok := document.([]any); ok {
+			items = append(items, list...)
+			continue
+		}
+		items = append(items, document)
+	}
+	return items, nil
+}
+
+// parseGenericDocuments parses JSON data or every non-empty YAML document into generic values
+func parseGenericDocuments(data []byte, format Format) ([]any, error) {
+	var documents []any
+	if format == FormatYAML {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		for {
+			var document any
+			err := decoder.Decode(&document)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse YAML: %w", err)
+			}
+			if document != nil {
+				documents = append(documents, document)
+			}
+		}
+		return documents, nil
+	}
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return append(documents, document), nil
+}
+
+func configFromGeneric(v any) (*gh.RepositoryRulesetConfig, error) {
+	if _, ok := v.(map[string]any); !ok {
+		return nil, fmt.Errorf("ruleset must be an object, got %T", v)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var config gh.RepositoryRulesetConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func readStdin() ([]byte, error) {
+	return io.ReadAll(os.Stdin)
+}
+
+// LoadConfig reads a single ruleset from a file, or from stdin when path is '-'.
+// The format is chosen by the file extension and detected from the content otherwise.
+func LoadConfig(path string) (*gh.RepositoryRulesetConfig, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = readStdin()
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(data, FormatFromPath(path))
+}
+
+// MarshalConfig serializes a ruleset in the given format.
+// YAML keeps the field order of the JSON export format.
+func MarshalConfig(config *gh.RepositoryRulesetConfig, format Format) ([]byte, error) {
+	jsonData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if format != FormatYAML {
+		return append(jsonData, '\n'), nil
+	}
+	node, err := yamlNodeFromJSON(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	return encodeYAMLNode(node)
+}
+
+// MarshalConfigs serializes rulesets in the given format, as a single ruleset when there is only one,
+// and otherwise as a JSON array or a multi-document YAML
+func MarshalConfigs(configs []*gh.RepositoryRulesetConfig, format Format) ([]byte, error) {
+	if len(configs) == 1 {
+		return MarshalConfig(configs[0], format)
+	}
+	if format != FormatYAML {
+		jsonData, err := json.MarshalIndent(configs, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(jsonData, '\n'), nil
+	}
+	var buf bytes.Buffer
+	for i, config := range configs {
+		data, err := MarshalConfig(config, format)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalConfigWithComments serializes a ruleset as YAML, carrying over the comments of an existing YAML document
+// to the fields and list items that still exist. Comments attached to removed fields are dropped.
+func MarshalConfigWithComments(config *gh.RepositoryRulesetConfig, existing []byte) ([]byte, error) {
+	jsonData, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	node, err := yamlNodeFromJSON(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	var old yaml.Node
+	if err := yaml.Unmarshal(existing, &old); err != nil {
+		return nil, fmt.Errorf("failed to parse existing YAML: %w", err)
+	}
+	if old.Kind == yaml.DocumentNode {
+		copyComments(&old, node)
+		if len(old.Content) > 0 && len(node.Content) > 0 {
+			mergeComments(old.Content[0], node.Content[0])
+		}
+	}
+	return encodeYAMLNode(node)
+}
+
+// WriteConfigFile writes a ruleset to a file in the given format.
+// When writing YAML over an existing YAML file, its comments are preserved where the commented field still exists.
+func WriteConfigFile(path string, config *gh.RepositoryRulesetConfig, format Format) error {
+	var data []byte
+	var err error
+	existing, readErr := os.ReadFile(path)
+	if format == FormatYAML && readErr == nil && FormatFromPath(path) == FormatYAML {
+		data, err = MarshalConfigWithComments(config, existing)
+	} else {
+		data, err = MarshalConfig(config, format)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func yamlNodeFromJSON(data []byte) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+	return &node, nil
+}
+
+// resetYAMLStyle switches nodes decoded from JSON to block style and plain scalars
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+func encodeYAMLNode(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func copyComments(from, to *yaml.Node) {
+	to.HeadComment = from.HeadComment
+	to.LineComment = from.LineComment
+	to.FootComment = from.FootComment
+}
+
+// mergeComments copies comments from old to the matching nodes of updated.
+// Mapping entries are matched by key and sequence items by the same keys used for diffs.
+func mergeComments(old, updated *yaml.Node) {
+	copyComments(old, updated)
+	if old.Kind != updated.Kind {
+		return
+	}
+	switch old.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(updated.Content); i += 2 {
+			key := updated.Content[i].Value
+			for j := 0; j+1 < len(old.Content); j += 2 {
+				if old.Content[j].Value == key {
+					copyComments(old.Content[j], updated.Content[i])
+					mergeComments(old.Content[j+1], updated.Content[i+1])
+					break
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		oldItems := map[string]*yaml.Node{}
+		for _, item := range old.Content {
+			if key, ok := yamlNodeKey(item); ok {
+				oldItems[key] = item
+			}
+		}
+		for _, item := range updated.Content {
+			key, ok := yamlNodeKey(item)
+			if !ok {
+				continue
+			}
+			if oldItem, ok := oldItems[key]; ok {
+				mergeComments(oldItem, item)
+			}
+		}
+	}
+}
+
+func yamlNodeKey(node *yaml.Node) (string, bool) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, true
+	}
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return "", false
+	}
+	generic, err := toGeneric(v)
+	if err != nil {
+		return "", false
+	}
+	return elementKey(generic)
+}
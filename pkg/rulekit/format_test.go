@@ -0,0 +1,63 @@
+package rulekit
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMarshalConfigWithComments(t *testing.T) {
+	existing := `# Protect the default branch
+name: main
+target: branch
+enforcement: active # keep active
+rules:
+  # no force pushes
+  - type: non_fast_forward
+  # removed below
+  - type: deletion
+`
+	config := mustConfig(t, `{"name":"main","target":"branch","enforcement":"evaluate","rules":[{"type":"creation"},{"type":"non_fast_forward"}]}`)
+	data, err := MarshalConfigWithComments(config, []byte(existing))
+	if err != nil {
+		t.Fatalf("MarshalConfigWithComments() error = %v", err)
+	}
+	got := string(data)
+	for _, want := range []string{"# Protect the default branch", "enforcement: evaluate # keep active", "# no force pushes\n  - type: non_fast_forward"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("MarshalConfigWithComments() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "# removed below") {
+		t.Errorf("MarshalConfigWithComments() = %q, want the comment of the removed rule dropped", got)
+	}
+}
+
+func TestParseConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format Format
+		want   []string
+	}{
+		{name: "single JSON", data: `{"name":"a","enforcement":"active"}`, format: FormatJSON, want: []string{"a"}},
+		{name: "JSON array", data: `[{"name":"a","enforcement":"active"},{"name":"b","enforcement":"active"}]`, format: FormatJSON, want: []string{"a", "b"}},
+		{name: "multi-document YAML", data: "name: a\nenforcement: active\n---\nname: b\nenforcement: active\n", format: FormatYAML, want: []string{"a", "b"}},
+		{name: "detected format", data: "name: a\nenforcement: active\n", want: []string{"a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configs, err := ParseConfigs([]byte(tt.data), tt.format)
+			if err != nil {
+				t.Fatalf("ParseConfigs() error = %v", err)
+			}
+			var names []string
+			for _, config := range configs {
+				names = append(names, config.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("ParseConfigs() names = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// CurrentVersion is the version argument that refers to the live ruleset instead of a past version
+const CurrentVersion = "current"
+
+// RulesetVersionActor is the actor who made a ruleset version
+type RulesetVersionActor struct {
+	ID    int64  `json:"id"`
+	Type  string `json:"type"`
+	Login string `json:"login,omitempty"`
+}
+
+// RulesetVersion is a version of a ruleset in its history. State is only returned for a single version.
+type RulesetVersion struct {
+	VersionID int64                     `json:"version_id"`
+	Actor     *RulesetVersionActor      `json:"actor,omitempty"`
+	UpdatedAt github.Timestamp          `json:"updated_at"`
+	State     *github.RepositoryRuleset `json:"state,omitempty"`
+}
+
+// ActorName returns the login of the actor when known, otherwise its type and ID
+func (v *RulesetVersion) ActorName() string {
+	if v.Actor == nil {
+		return ""
+	}
+	if v.Actor.Login != "" {
+		return v.Actor.Login
+	}
+	return fmt.Sprintf("%s %d", v.Actor.Type, v.Actor.ID)
+}
+
+// rulesetHistoryPath returns the REST path of the history of a repository or organization (repo.Name is empty) ruleset
+func rulesetHistoryPath(repo repository.Repository, rulesetID int64) string {
+	return rulesetPath(repo, rulesetID) + "/history"
+}
+
+// ListRulesetHistory returns the versions of a repository or organization (organization when repo.Name is empty)
+// ruleset, newest first. The logins of user actors are looked up on a best-effort basis.
+func ListRulesetHistory(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, rulesetID int64) ([]*RulesetVersion, error) {
+	client := g.GetClient()
+	var all []*RulesetVersion
+	page := 1
+	for {
+		u := fmt.Sprintf("%s?per_page=100&page=%d", rulesetHistoryPath(repo, rulesetID), page)
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		var versions []*RulesetVersion
+		resp, err := client.Do(ctx, req, &versions)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, versions...)
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	resolveVersionActors(ctx, g, all)
+	return all, nil
+}
+
+// GetRulesetVersion returns a single version of a ruleset including its state
+func GetRulesetVersion(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, rulesetID int64, versionID int64) (*RulesetVersion, error) {
+	client := g.GetClient()
+	req, err := client.NewRequest("GET", fmt.Sprintf("%s/%d", rulesetHistoryPath(repo, rulesetID), versionID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var version RulesetVersion
+	if _, err := client.Do(ctx, req, &version); err != nil {
+		return nil, err
+	}
+	resolveVersionActors(ctx, g, []*RulesetVersion{&version})
+	return &version, nil
+}
+
+// ExportRulesetVersion returns a ruleset version, or the live ruleset for CurrentVersion, in the export format
+func ExportRulesetVersion(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, ruleset *github.RepositoryRuleset, version string) (*gh.RepositoryRulesetConfig, error) {
+	if version == CurrentVersion {
+		return gh.ExportRuleset(ruleset), nil
+	}
+	versionID, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version '%s'", version)
+	}
+	v, err := GetRulesetVersion(ctx, g, repo, ruleset.GetID(), versionID)
+	if err != nil {
+		return nil, err
+	}
+	if v.State == nil {
+		return nil, fmt.Errorf("version %d has no state", versionID)
+	}
+	return gh.ExportRuleset(v.State), nil
+}
+
+// resolveVersionActors fills in the logins of user actors. Actors that cannot be looked up keep their ID only.
+func resolveVersionActors(ctx context.Context, g *gh.GitHubClient, versions []*RulesetVersion) {
+	logins := map[int64]string{}
+	for _, version := range versions {
+		if version.Actor == nil || version.Actor.Type != "User" {
+			continue
+		}
+		login, ok := logins[version.Actor.ID]
+		if !ok {
+			if user, err := g.GetUserByID(ctx, version.Actor.ID); err == nil {
+				login = user.GetLogin()
+			}
+			logins[version.Actor.ID] = login
+		}
+		version.Actor.Login = login
+	}
+}
+
+// RevertRuleset updates the live ruleset with the given ID back to config, a past version exported with
+// ExportRulesetVersion. The ruleset is updated by ID only, since its name may have changed or be used by another
+// ruleset since the version was made. The live ruleset is backed up first.
+func RevertRuleset(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, id int64, config *gh.RepositoryRulesetConfig) (*github.RepositoryRuleset, error) {
+	return newRulesetAPI(g, repo, false).updateConfigAs(ctx, id, config, "revert")
+}
@@ -0,0 +1,99 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// FindConfigRuleset finds the live ruleset matching a ruleset configuration by ID and then by name,
+// in a repository or an organization (organization when repo.Name is empty). It returns nil when none matches.
+func FindConfigRuleset(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, config *gh.RepositoryRulesetConfig) (*github.RepositoryRuleset, error) {
+	return newRulesetAPI(g, repo, false).findConfig(ctx, config)
+}
+
+// PlanImport computes the plan of importing a ruleset configuration.
+// It fails when no live ruleset matches and createIfNotExists is false.
+func PlanImport(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, target string, config *gh.RepositoryRulesetConfig, createIfNotExists bool) (*Plan, error) {
+	return newRulesetAPI(g, repo, false).planImport(ctx, target, config, createIfNotExists)
+}
+
+// ImportConfig updates the live ruleset matching a ruleset configuration, or creates it when none matches
+// and createIfNotExists is true. It returns the resulting ruleset and the action that was taken.
+func ImportConfig(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, config *gh.RepositoryRulesetConfig, createIfNotExists bool) (*github.RepositoryRuleset, ApplyAction, error) {
+	return newRulesetAPI(g, repo, false).importConfig(ctx, config, createIfNotExists)
+}
+
+func (api *rulesetAPI) planImport(ctx context.Context, target string, config *gh.RepositoryRulesetConfig, createIfNotExists bool) (*Plan, error) {
+	found, err := api.findImportRuleset(ctx, config, createIfNotExists)
+	if err != nil {
+		return nil, err
+	}
+	current, err := api.exportCurrent(ctx, found)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current ruleset: %w", err)
+	}
+	return NewPlan(target, current, config)
+}
+
+func (api *rulesetAPI) importConfig(ctx context.Context, config *gh.RepositoryRulesetConfig, createIfNotExists bool) (*github.RepositoryRuleset, ApplyAction, error) {
+	return api.importConfigAs(ctx, config, createIfNotExists, "import")
+}
+
+// importConfigAs imports a ruleset configuration, backing up the live ruleset as operation before updating it
+func (api *rulesetAPI) importConfigAs(ctx context.Context, config *gh.RepositoryRulesetConfig, createIfNotExists bool, operation string) (*github.RepositoryRuleset, ApplyAction, error) {
+	found, err := api.findImportRuleset(ctx, config, createIfNotExists)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ruleset := gh.ImportRuleset(config, found)
+	if found == nil {
+		created, err := api.create(ctx, ruleset)
+		if err != nil {
+			return nil, ApplyActionCreate, fmt.Errorf("failed to create ruleset: %w", err)
+		}
+		return created, ApplyActionCreate, nil
+	}
+	if err := api.backup(ctx, found.GetID(), operation); err != nil {
+		return nil, ApplyActionUpdate, err
+	}
+	updated, err := api.update(ctx, found.GetID(), ruleset)
+	if err != nil {
+		return nil, ApplyActionUpdate, fmt.Errorf("failed to update ruleset: %w", err)
+	}
+	return updated, ApplyActionUpdate, nil
+}
+
+// updateConfigAs updates the live ruleset with the given ID to a ruleset configuration, backing it up as operation first
+func (api *rulesetAPI) updateConfigAs(ctx context.Context, id int64, config *gh.RepositoryRulesetConfig, operation string) (*github.RepositoryRuleset, error) {
+	found, err := api.get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ruleset: %w", err)
+	}
+	if err := api.backup(ctx, id, operation); err != nil {
+		return nil, err
+	}
+	updated, err := api.update(ctx, id, gh.ImportRuleset(config, found))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update ruleset: %w", err)
+	}
+	return updated, nil
+}
+
+func (api *rulesetAPI) findImportRuleset(ctx context.Context, config *gh.RepositoryRulesetConfig, createIfNotExists bool) (*github.RepositoryRuleset, error) {
+	found, err := api.findConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ruleset: %w", err)
+	}
+	if found == nil && !createIfNotExists {
+		if config.ID != nil {
+			return nil, fmt.Errorf("ruleset not found with ID %d or name '%s'", *config.ID, config.Name)
+		}
+		return nil, fmt.Errorf("ruleset not found with name '%s'", config.Name)
+	}
+	return found, nil
+}
@@ -0,0 +1,260 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh/client"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+)
+
+// InsightDimension is what rule suite statistics are grouped by
+type InsightDimension string
+
+const (
+	InsightDimensionRuleset    InsightDimension = "ruleset"
+	InsightDimensionRule       InsightDimension = "rule"
+	InsightDimensionActor      InsightDimension = "actor"
+	InsightDimensionRef        InsightDimension = "ref"
+	InsightDimensionRepository InsightDimension = "repository"
+)
+
+// InsightDimensions lists the dimensions accepted by --by
+var InsightDimensions = []string{
+	string(InsightDimensionRuleset),
+	string(InsightDimensionRule),
+	string(InsightDimensionActor),
+	string(InsightDimensionRef),
+	string(InsightDimensionRepository),
+}
+
+// RepositoryInsightDimensions are the dimensions repository statistics are grouped by unless given
+var RepositoryInsightDimensions = []InsightDimension{InsightDimensionRuleset, InsightDimensionRule, InsightDimensionActor, InsightDimensionRef}
+
+// OrgInsightDimensions are the dimensions organization statistics are grouped by unless given
+var OrgInsightDimensions = []InsightDimension{InsightDimensionRepository, InsightDimensionRuleset, InsightDimensionRule, InsightDimensionActor, InsightDimensionRef}
+
+// Rule suite and rule evaluation results
+const (
+	RuleSuiteResultPass   = "pass"
+	RuleSuiteResultFail   = "fail"
+	RuleSuiteResultBypass = "bypass"
+)
+
+// InsightStat counts the outcomes of a single key of a dimension, such as a ruleset name or an actor.
+// Actor, ref and repository count rule suites; ruleset and rule count rule evaluations, where a failed
+// evaluation of a bypassed rule suite counts as a bypass.
+type InsightStat struct {
+	Dimension InsightDimension `json:"dimension"`
+	Key       string           `json:"key"`
+	Total     int              `json:"total"`
+	Pass      int              `json:"pass"`
+	Fail      int              `json:"fail"`
+	Bypass    int              `json:"bypass"`
+	// FailRate and BypassRate are the shares of failures and bypasses in the total
+	FailRate   float64 `json:"fail_rate"`
+	BypassRate float64 `json:"bypass_rate"`
+}
+
+func (s *InsightStat) add(result string) {
+	s.Total++
+	switch result {
+	case RuleSuiteResultPass:
+		s.Pass++
+	case RuleSuiteResultFail:
+		s.Fail++
+	case RuleSuiteResultBypass:
+		s.Bypass++
+	}
+	s.FailRate = float64(s.Fail) / float64(s.Total)
+	s.BypassRate = float64(s.Bypass) / float64(s.Total)
+}
+
+// ParseInsightDimensions validates dimensions given with --by, returning defaults when none are given
+func ParseInsightDimensions(values []string, defaults []InsightDimension) ([]InsightDimension, error) {
+	if len(values) == 0 {
+		return defaults, nil
+	}
+	dimensions := make([]InsightDimension, 0, len(values))
+	for _, value := range values {
+		if !slices.Contains(InsightDimensions, value) {
+			return nil, fmt.Errorf("invalid dimension '%s', expected one of %v", value, InsightDimensions)
+		}
+		dimensions = append(dimensions, InsightDimension(value))
+	}
+	return dimensions, nil
+}
+
+// NeedsRuleEvaluations reports whether any of the dimensions needs the rule evaluations of rule suites,
+// which are only returned when rule suites are fetched one by one
+func NeedsRuleEvaluations(dimensions []InsightDimension) bool {
+	return slices.Contains(dimensions, InsightDimensionRuleset) || slices.Contains(dimensions, InsightDimensionRule)
+}
+
+// GetRuleSuiteDetails fetches each rule suite of a repository or organization (organization when repo.Name is empty)
+// with its rule evaluations. Rule suites that cannot be fetched are logged and left out.
+func GetRuleSuiteDetails(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, suites []*gh.RuleSuite) []*gh.RuleSuite {
+	details := make([]*gh.RuleSuite, 0, len(suites))
+	for i, suite := range suites {
+		detail, err := getRuleSuiteDetail(ctx, g, repo, suite)
+		if err != nil {
+			logger.Warn("Failed to get rule suite, skipping...", "id", valueOf(suite.ID), "error", err)
+			continue
+		}
+		logger.Debug("Fetched rule suite", "id", valueOf(suite.ID), "progress", fmt.Sprintf("%d/%d", i+1, len(suites)))
+		details = append(details, detail)
+	}
+	return details
+}
+
+// getRuleSuiteDetail fetches a rule suite with its rule evaluations from a repository or organization
+func getRuleSuiteDetail(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, suite *gh.RuleSuite) (*gh.RuleSuite, error) {
+	if repo.Name == "" {
+		return gh.GetOrgRuleSuite(ctx, g, repo, valueOf(suite.ID))
+	}
+	return gh.GetRepositoryRuleSuite(ctx, g, repo, valueOf(suite.ID))
+}
+
+// AggregateRuleSuites counts the outcomes of rule suites for each dimension. Stats are ordered by dimension in the
+// given order, then by failures and bypasses, most first, then by key. top limits the stats per dimension when positive.
+func AggregateRuleSuites(suites []*gh.RuleSuite, dimensions []InsightDimension, top int) []*InsightStat {
+	var result []*InsightStat
+	for _, dimension := range dimensions {
+		stats := map[string]*InsightStat{}
+		count := func(key string, outcome string) {
+			stat, ok := stats[key]
+			if !ok {
+				stat = &InsightStat{Dimension: dimension, Key: key}
+				stats[key] = stat
+			}
+			stat.add(outcome)
+		}
+		for _, suite := range suites {
+			switch dimension {
+			case InsightDimensionActor:
+				count(valueOf(suite.ActorName), valueOf(suite.Result))
+			case InsightDimensionRef:
+				count(valueOf(suite.Ref), valueOf(suite.Result))
+			case InsightDimensionRepository:
+				count(valueOf(suite.RepositoryName), valueOf(suite.Result))
+			case InsightDimensionRuleset, InsightDimensionRule:
+				for _, evaluation := range suite.RuleEvaluations {
+					outcome := valueOf(evaluation.Result)
+					if outcome == RuleSuiteResultFail && valueOf(suite.Result) == RuleSuiteResultBypass {
+						outcome = RuleSuiteResultBypass
+					}
+					if dimension == InsightDimensionRule {
+						count(valueOf(evaluation.RuleType), outcome)
+					} else {
+						count(ruleSourceName(evaluation), outcome)
+					}
+				}
+			}
+		}
+		sorted := make([]*InsightStat, 0, len(stats))
+		for _, stat := range stats {
+			sorted = append(sorted, stat)
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			if a.Fail+a.Bypass != b.Fail+b.Bypass {
+				return a.Fail+a.Bypass > b.Fail+b.Bypass
+			}
+			return a.Key < b.Key
+		})
+		if top > 0 && len(sorted) > top {
+			sorted = sorted[:top]
+		}
+		result = append(result, sorted...)
+	}
+	return result
+}
+
+// ruleSourceName returns the name of the ruleset a rule evaluation comes from, or its type and ID when it has no name
+func ruleSourceName(evaluation *client.RuleSuiteRuleEvaluation) string {
+	source := evaluation.RuleSource
+	if source == nil {
+		return ""
+	}
+	if valueOf(source.Name) != "" {
+		return valueOf(source.Name)
+	}
+	return fmt.Sprintf("%s %d", valueOf(source.Type), valueOf(source.ID))
+}
+
+// valueOf returns the value a pointer of a rule suite field points to, or the zero value for nil
+func valueOf[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// RepositoryDrillDown is the ruleset and rule statistics of a single repository of an organization rollup
+type RepositoryDrillDown struct {
+	Repository string         `json:"repository"`
+	Stats      []*InsightStat `json:"stats"`
+}
+
+// OrgRollup is the rule suite statistics of an organization grouped by repository. Rules and Details are only
+// filled in for the repositories that were drilled down into.
+type OrgRollup struct {
+	Repositories []*InsightStat         `json:"repositories"`
+	Rules        []*InsightStat         `json:"rules,omitempty"`
+	Details      []*RepositoryDrillDown `json:"details,omitempty"`
+}
+
+// RollupOrgRuleSuites groups organization rule suites by repository, most failures and bypasses first.
+// top limits the repositories and rules shown when positive.
+func RollupOrgRuleSuites(suites []*gh.RuleSuite, top int) *OrgRollup {
+	return &OrgRollup{Repositories: AggregateRuleSuites(suites, []InsightDimension{InsightDimensionRepository}, top)}
+}
+
+// DrillDown fetches the rule evaluations of the rule suites of the given repositories from the repository API
+// and adds their ruleset and rule statistics, along with the rules that cause the most blocks across them.
+// limit caps the number of most recent rule suites fetched per repository when positive.
+func (r *OrgRollup) DrillDown(ctx context.Context, g *gh.GitHubClient, org repository.Repository, suites []*gh.RuleSuite, repositories []string, limit int, top int) {
+	var all []*gh.RuleSuite
+	seen := map[string]bool{}
+	for _, name := range repositories {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		var repoSuites []*gh.RuleSuite
+		for _, suite := range suites {
+			if valueOf(suite.RepositoryName) == name {
+				repoSuites = append(repoSuites, suite)
+			}
+		}
+		if limit > 0 && len(repoSuites) > limit {
+			repoSuites = repoSuites[:limit]
+		}
+		repo := repository.Repository{Host: org.Host, Owner: org.Owner, Name: name}
+		details := GetRuleSuiteDetails(ctx, g, repo, repoSuites)
+		r.Details = append(r.Details, &RepositoryDrillDown{
+			Repository: name,
+			Stats:      AggregateRuleSuites(details, []InsightDimension{InsightDimensionRuleset, InsightDimensionRule}, top),
+		})
+		all = append(all, details...)
+	}
+	r.Rules = AggregateRuleSuites(all, []InsightDimension{InsightDimensionRule}, top)
+}
+
+// TopRepositories returns the names of up to n repositories of the rollup with the most failures and bypasses,
+// leaving out repositories without any
+func (r *OrgRollup) TopRepositories(n int) []string {
+	var names []string
+	for _, stat := range r.Repositories {
+		if len(names) >= n || stat.Fail+stat.Bypass == 0 {
+			break
+		}
+		names = append(names, stat.Key)
+	}
+	return names
+}
@@ -0,0 +1,296 @@
+package rulekit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// MigrationStatus is the outcome of migrating rulesets to a single destination
+type MigrationStatus string
+
+const (
+	MigrationStatusSuccess MigrationStatus = "success"
+	MigrationStatusPartial MigrationStatus = "partial"
+	MigrationStatusFailed  MigrationStatus = "failed"
+)
+
+// MigrationResult is the outcome of migrating rulesets to a single destination.
+// Migrated includes the rulesets that were skipped because they already exist or are not supported by the destination.
+type MigrationResult struct {
+	Destination string          `json:"destination"`
+	Status      MigrationStatus `json:"status"`
+	Total       int             `json:"total"`
+	Migrated    int             `json:"migrated"`
+	Skipped     int             `json:"skipped"`
+	Unsupported int             `json:"unsupported"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// NewMigrationResult creates the result of migrating total rulesets to a destination, of which migrated succeeded.
+// A non-nil err means the destination could not be migrated at all.
+func NewMigrationResult(destination string, total int, migrated int, err error) *MigrationResult {
+	result := &MigrationResult{Destination: destination, Total: total, Migrated: migrated}
+	switch {
+	case err != nil:
+		result.Status = MigrationStatusFailed
+		result.Error = err.Error()
+	case migrated < total && migrated == 0:
+		result.Status = MigrationStatusFailed
+	case migrated < total:
+		result.Status = MigrationStatusPartial
+	default:
+		result.Status = MigrationStatusSuccess
+	}
+	return result
+}
+
+// CountFailedMigrations returns the number of destinations that no ruleset could be migrated to
+func CountFailedMigrations(results []*MigrationResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Status == MigrationStatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// CopyMigrateConfig returns a deep copy of an exported migration config.
+// Importing a migration config rewrites it for its destination, so each destination needs its own copy.
+func CopyMigrateConfig(config *gh.RepositoryRulesetMigrateConfig) (*gh.RepositoryRulesetMigrateConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var copied gh.RepositoryRulesetMigrateConfig
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return &copied, nil
+}
+
+// ConflictStrategy decides what happens when a ruleset with the same name already exists in the destination
+type ConflictStrategy string
+
+const (
+	// ConflictSkip leaves the existing ruleset as is
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictUpdate updates the existing ruleset in place, keeping its ID
+	ConflictUpdate ConflictStrategy = "update"
+	// ConflictReplace deletes the existing ruleset and creates a new one
+	ConflictReplace ConflictStrategy = "replace"
+	// ConflictRename creates the ruleset under a free name, such as 'name (2)'
+	ConflictRename ConflictStrategy = "rename"
+	// ConflictFail fails the migration of the ruleset
+	ConflictFail ConflictStrategy = "fail"
+)
+
+// ConflictStrategies lists the values accepted by --on-conflict
+var ConflictStrategies = []string{
+	string(ConflictSkip),
+	string(ConflictUpdate),
+	string(ConflictReplace),
+	string(ConflictRename),
+	string(ConflictFail),
+}
+
+// MigrationAction is what was done with a single migrated ruleset
+type MigrationAction string
+
+const (
+	MigrationActionCreated     MigrationAction = "created"
+	MigrationActionUpdated     MigrationAction = "updated"
+	MigrationActionReplaced    MigrationAction = "replaced"
+	MigrationActionRenamed     MigrationAction = "renamed"
+	MigrationActionSkipped     MigrationAction = "skipped"
+	MigrationActionUnsupported MigrationAction = "unsupported"
+)
+
+// ImportMigrateRuleset imports a migration config into the destination. A ruleset of the same name in the destination
+// is resolved with strategy and backed up before it is updated or replaced. The integrations of required status checks
+// mapped with an actor map are set again after the import. The returned ruleset is nil when the ruleset was skipped or
+// is not supported by the destination. Team bypass actors that are not found in the destination fail the import,
+// instead of being dropped from the imported ruleset.
+func ImportMigrateRuleset(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, config *gh.RepositoryRulesetMigrateConfig, strategy ConflictStrategy, gitHubActionsAppID *int64, integrations IntegrationMap) (*github.RepositoryRuleset, MigrationAction, error) {
+	if err := checkTeamActors(ctx, g, repo, config); err != nil {
+		return nil, "", err
+	}
+	rulesets, err := gh.ListRulesets(ctx, g, repo, false)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list destination rulesets: %w", err)
+	}
+	var existing *github.RepositoryRuleset
+	for _, ruleset := range rulesets {
+		if ruleset.Name == config.Ruleset.Name {
+			existing = ruleset
+			break
+		}
+	}
+
+	action := MigrationActionCreated
+	if existing != nil {
+		switch strategy {
+		case ConflictSkip:
+			return nil, MigrationActionSkipped, nil
+		case ConflictUpdate:
+			if err := newRulesetAPI(g, repo, false).backup(ctx, existing.GetID(), "migrate"); err != nil {
+				return nil, "", err
+			}
+			// The import updates the ruleset found by ID
+			config.Ruleset.ID = existing.ID
+			action = MigrationActionUpdated
+		case ConflictReplace:
+			if err := newRulesetAPI(g, repo, false).remove(ctx, existing.GetID(), "migrate"); err != nil {
+				return nil, "", fmt.Errorf("failed to delete existing ruleset '%s': %w", existing.Name, err)
+			}
+			action = MigrationActionReplaced
+		case ConflictRename:
+			config.Ruleset.Name = freeRulesetName(config.Ruleset.Name, rulesets)
+			action = MigrationActionRenamed
+		default:
+			return nil, "", fmt.Errorf("ruleset '%s' already exists in the destination (id: %d)", existing.Name, existing.GetID())
+		}
+	}
+
+	created, err := gh.ImportMigrateRuleset(ctx, g, repo, config, gitHubActionsAppID)
+	if err != nil {
+		return nil, "", err
+	}
+	if created == nil {
+		return nil, MigrationActionUnsupported, nil
+	}
+	restored, err := newRulesetAPI(g, repo, false).restoreIntegrations(ctx, created, integrations)
+	if err != nil {
+		return nil, "", fmt.Errorf("ruleset '%s' was imported as %d but its status check integrations could not be mapped: %w", created.Name, created.GetID(), err)
+	}
+	return restored, action, nil
+}
+
+// checkTeamActors fails with every team bypass actor of a migration config that the import would drop, because it is
+// neither a team of the destination nor a source team with a team of the same slug in the destination
+func checkTeamActors(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, config *gh.RepositoryRulesetMigrateConfig) error {
+	hasTeams := false
+	for _, actor := range config.Ruleset.BypassActors {
+		if actor.ActorType != nil && *actor.ActorType == github.BypassActorTypeTeam {
+			hasTeams = true
+			break
+		}
+	}
+	if !hasTeams {
+		return nil
+	}
+	teamTree, err := gh.TeamByOwner(ctx, g, repo, true)
+	if err != nil {
+		return fmt.Errorf("failed to list destination teams: %w", err)
+	}
+	if missing := missingTeamActors(config, teamTree.Flatten()); len(missing) > 0 {
+		return fmt.Errorf("bypass actors not found in the destination, map them with an actor map: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// missingTeamActors returns the team bypass actors of a migration config that are not found in teams, by ID or by the
+// slug of the source team
+func missingTeamActors(config *gh.RepositoryRulesetMigrateConfig, teams []*github.Team) []string {
+	var missing []string
+	for _, actor := range config.Ruleset.BypassActors {
+		if actor.ActorType == nil || *actor.ActorType != github.BypassActorTypeTeam {
+			continue
+		}
+		id := actor.GetActorID()
+		source := config.Teams[id]
+		found := false
+		for _, team := range teams {
+			if team.GetID() == id || (source != nil && team.GetSlug() == source.GetSlug()) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if source != nil {
+			missing = append(missing, fmt.Sprintf("Team '%s' (id: %d)", source.GetSlug(), id))
+		} else {
+			missing = append(missing, fmt.Sprintf("Team %d", id))
+		}
+	}
+	return missing
+}
+
+// restoreIntegrations sets the integrations of required status checks given by integrations on an imported ruleset.
+// The import resolves integrations from the check runs of the destination and drops those it cannot find, which
+// would lose the integrations mapped with an actor map on a destination without check runs.
+func (api *rulesetAPI) restoreIntegrations(ctx context.Context, ruleset *github.RepositoryRuleset, integrations IntegrationMap) (*github.RepositoryRuleset, error) {
+	if len(integrations) == 0 {
+		return ruleset, nil
+	}
+	full, err := api.get(ctx, ruleset.GetID())
+	if err != nil {
+		return nil, err
+	}
+	var checks []*github.RuleStatusCheck
+	if full.Rules != nil && full.Rules.RequiredStatusChecks != nil {
+		checks = full.Rules.RequiredStatusChecks.RequiredStatusChecks
+	}
+	changed := false
+	found := map[string]bool{}
+	for _, check := range checks {
+		id, ok := integrations[check.Context]
+		if !ok {
+			continue
+		}
+		found[check.Context] = true
+		if (check.IntegrationID == nil) != (id == nil) || (id != nil && *check.IntegrationID != *id) {
+			check.IntegrationID = id
+			changed = true
+		}
+	}
+	for context := range integrations {
+		if !found[context] {
+			return nil, fmt.Errorf("status check '%s' is missing from the imported ruleset", context)
+		}
+	}
+	if !changed {
+		return full, nil
+	}
+	return api.update(ctx, full.GetID(), full)
+}
+
+// ResolvePlanConflict returns the destination ruleset a migration plan is computed against when existing has the same name.
+// It returns false when the ruleset would be skipped, and nil for rename and replace, which create a new ruleset.
+func ResolvePlanConflict(strategy ConflictStrategy, existing *github.RepositoryRuleset) (*github.RepositoryRuleset, bool, error) {
+	if existing == nil {
+		return nil, true, nil
+	}
+	switch strategy {
+	case ConflictSkip:
+		return nil, false, nil
+	case ConflictUpdate:
+		return existing, true, nil
+	case ConflictReplace, ConflictRename:
+		return nil, true, nil
+	default:
+		return nil, false, fmt.Errorf("ruleset '%s' already exists in the destination (id: %d)", existing.Name, existing.GetID())
+	}
+}
+
+// freeRulesetName returns the first of 'name (2)', 'name (3)', ... that no ruleset has
+func freeRulesetName(name string, rulesets []*github.RepositoryRuleset) string {
+	names := make(map[string]bool, len(rulesets))
+	for _, ruleset := range rulesets {
+		names[ruleset.Name] = true
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if !names[candidate] {
+			return candidate
+		}
+	}
+}
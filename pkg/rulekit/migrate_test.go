@@ -0,0 +1,39 @@
+package rulekit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+func TestMissingTeamActors(t *testing.T) {
+	team := github.BypassActorTypeTeam
+	admin := github.BypassActorTypeOrganizationAdmin
+	config := &gh.RepositoryRulesetMigrateConfig{
+		Ruleset: &github.RepositoryRuleset{
+			Name: "main",
+			BypassActors: []*github.BypassActor{
+				{ActorID: github.Ptr(int64(1)), ActorType: &admin},
+				{ActorID: github.Ptr(int64(10)), ActorType: &team},
+				{ActorID: github.Ptr(int64(11)), ActorType: &team},
+				{ActorID: github.Ptr(int64(12)), ActorType: &team},
+				{ActorID: github.Ptr(int64(13)), ActorType: &team},
+			},
+		},
+		Teams: map[int64]*github.Team{
+			11: {ID: github.Ptr(int64(11)), Slug: github.Ptr("core")},
+			12: {ID: github.Ptr(int64(12)), Slug: github.Ptr("docs")},
+		},
+	}
+	teams := []*github.Team{
+		{ID: github.Ptr(int64(10)), Slug: github.Ptr("ops")},
+		{ID: github.Ptr(int64(21)), Slug: github.Ptr("core")},
+	}
+	got := missingTeamActors(config, teams)
+	want := []string{"Team 'docs' (id: 12)", "Team 13"}
+	if !slices.Equal(got, want) {
+		t.Errorf("missingTeamActors() = %v, want %v", got, want)
+	}
+}
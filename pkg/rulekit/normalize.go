@@ -0,0 +1,62 @@
+package rulekit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// NormalizeConfig returns a copy of the config without fields that identify where the ruleset lives
+// (ID and source), so that the same ruleset compares equal across repositories and organizations
+func NormalizeConfig(config *gh.RepositoryRulesetConfig) *gh.RepositoryRulesetConfig {
+	if config == nil {
+		return nil
+	}
+	normalized := *config
+	normalized.ID = nil
+	normalized.Source = ""
+	normalized.SourceType = nil
+	return &normalized
+}
+
+// NormalizeRuleset converts a ruleset to its normalized export format
+func NormalizeRuleset(ruleset *github.RepositoryRuleset) *gh.RepositoryRulesetConfig {
+	if ruleset == nil {
+		return nil
+	}
+	return NormalizeConfig(gh.ExportRuleset(ruleset))
+}
+
+// EqualConfig reports whether two ruleset configs are semantically equal, which is when DiffConfig finds no changes.
+// Keyed arrays such as rules, bypass actors and status checks are compared regardless of their order, so the result
+// always agrees with the plan.
+func EqualConfig(a, b *gh.RepositoryRulesetConfig) (bool, error) {
+	changes, err := DiffConfig(a, b)
+	if err != nil {
+		return false, err
+	}
+	return len(changes) == 0, nil
+}
+
+// toGeneric converts a value to its generic JSON representation (maps, slices and scalars)
+func toGeneric(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// ExportCurrentRuleset fetches the full ruleset and converts it to the export format.
+// Rulesets returned by the list API do not contain rules and conditions, so they must be fetched again before comparing.
+// It returns nil when ruleset is nil.
+func ExportCurrentRuleset(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, ruleset *github.RepositoryRuleset) (*gh.RepositoryRulesetConfig, error) {
+	return newRulesetAPI(g, repo, false).exportCurrent(ctx, ruleset)
+}
@@ -0,0 +1,86 @@
+package rulekit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+func mustConfig(t *testing.T, s string) *gh.RepositoryRulesetConfig {
+	t.Helper()
+	var config gh.RepositoryRulesetConfig
+	if err := json.Unmarshal([]byte(s), &config); err != nil {
+		t.Fatalf("failed to parse config: %v", err)
+	}
+	return &config
+}
+
+func TestEqualConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{
+			name:  "identical",
+			a:     `{"name":"main","target":"branch","enforcement":"active"}`,
+			b:     `{"name":"main","target":"branch","enforcement":"active"}`,
+			equal: true,
+		},
+		{
+			name:  "ID and source are ignored",
+			a:     `{"id":1,"name":"main","source":"o/a","target":"branch","enforcement":"active"}`,
+			b:     `{"id":2,"name":"main","source":"o/b","target":"branch","enforcement":"active"}`,
+			equal: true,
+		},
+		{
+			name:  "reordered bypass actors",
+			a:     `{"name":"main","enforcement":"active","bypass_actors":[{"actor_id":5,"actor_type":"RepositoryRole","bypass_mode":"always"},{"actor_id":7,"actor_type":"Team","bypass_mode":"always"}]}`,
+			b:     `{"name":"main","enforcement":"active","bypass_actors":[{"actor_id":7,"actor_type":"Team","bypass_mode":"always"},{"actor_id":5,"actor_type":"RepositoryRole","bypass_mode":"always"}]}`,
+			equal: true,
+		},
+		{
+			name:  "reordered rules and status checks",
+			a:     `{"name":"main","enforcement":"active","rules":[{"type":"deletion"},{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":true,"required_status_checks":[{"context":"a"},{"context":"b"}]}}]}`,
+			b:     `{"name":"main","enforcement":"active","rules":[{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":true,"required_status_checks":[{"context":"b"},{"context":"a"}]}},{"type":"deletion"}]}`,
+			equal: true,
+		},
+		{
+			name:  "reordered include patterns",
+			a:     `{"name":"main","enforcement":"active","conditions":{"ref_name":{"include":["refs/heads/a","refs/heads/b"],"exclude":[]}}}`,
+			b:     `{"name":"main","enforcement":"active","conditions":{"ref_name":{"include":["refs/heads/b","refs/heads/a"],"exclude":[]}}}`,
+			equal: true,
+		},
+		{
+			name:  "changed enforcement",
+			a:     `{"name":"main","enforcement":"active"}`,
+			b:     `{"name":"main","enforcement":"evaluate"}`,
+			equal: false,
+		},
+		{
+			name:  "changed bypass mode",
+			a:     `{"name":"main","enforcement":"active","bypass_actors":[{"actor_id":5,"actor_type":"RepositoryRole","bypass_mode":"always"}]}`,
+			b:     `{"name":"main","enforcement":"active","bypass_actors":[{"actor_id":5,"actor_type":"RepositoryRole","bypass_mode":"pull_request"}]}`,
+			equal: false,
+		},
+		{
+			name:  "changed app of a status check sharing its context",
+			a:     `{"name":"main","enforcement":"active","rules":[{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":false,"required_status_checks":[{"context":"ci","integration_id":1},{"context":"ci","integration_id":2}]}}]}`,
+			b:     `{"name":"main","enforcement":"active","rules":[{"type":"required_status_checks","parameters":{"strict_required_status_checks_policy":false,"required_status_checks":[{"context":"ci","integration_id":1},{"context":"ci","integration_id":3}]}}]}`,
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, err := EqualConfig(mustConfig(t, tt.a), mustConfig(t, tt.b))
+			if err != nil {
+				t.Fatalf("EqualConfig() error = %v", err)
+			}
+			if equal != tt.equal {
+				t.Errorf("EqualConfig() = %v, want %v", equal, tt.equal)
+			}
+		})
+	}
+}
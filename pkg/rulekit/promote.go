@@ -0,0 +1,130 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// ImpactCount is the number of pushes that would have been blocked for a single actor, ref, rule or repository
+type ImpactCount struct {
+	Key     string `json:"key"`
+	Blocked int    `json:"blocked"`
+}
+
+// PromotionImpact is what a ruleset in evaluate mode would have blocked had it been active during the evaluation window
+type PromotionImpact struct {
+	RulesetID    int64          `json:"ruleset_id"`
+	Ruleset      string         `json:"ruleset"`
+	Enforcement  string         `json:"enforcement"`
+	RuleSuites   int            `json:"rule_suites"`
+	Failed       int            `json:"failed"`
+	Inspected    int            `json:"inspected"`
+	Truncated    bool           `json:"truncated"`
+	Blocked      int            `json:"blocked"`
+	Actors       []*ImpactCount `json:"actors"`
+	Refs         []*ImpactCount `json:"refs"`
+	Rules        []*ImpactCount `json:"rules"`
+	Repositories []*ImpactCount `json:"repositories,omitempty"`
+}
+
+// AnalyzePromotion reads the rule suites of a repository or organization (organization when repo.Name is empty)
+// selected by options and counts the pushes the ruleset, which must be in evaluate mode, would have blocked.
+// Only rule suites whose evaluate mode rules failed are fetched one by one; limit caps their number when positive, in
+// which case Truncated is set and Blocked is only a lower bound. Failing to fetch any of them is an error, since a
+// skipped rule suite would make the count too low.
+func AnalyzePromotion(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, ruleset *github.RepositoryRuleset, options *gh.ListRuleSuitesOptions, limit int) (*PromotionImpact, error) {
+	if ruleset.Enforcement != github.RulesetEnforcementEvaluate {
+		return nil, fmt.Errorf("ruleset '%s' is not in evaluate mode (enforcement: %s)", ruleset.Name, ruleset.Enforcement)
+	}
+
+	var suites []*gh.RuleSuite
+	var err error
+	if repo.Name == "" {
+		suites, err = gh.ListOrgRuleSuites(ctx, g, repo, options)
+	} else {
+		suites, err = gh.ListRepositoryRuleSuites(ctx, g, repo, options)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule suites: %w", err)
+	}
+
+	var failed []*gh.RuleSuite
+	for _, suite := range suites {
+		if valueOf(suite.EvaluationResult) == RuleSuiteResultFail {
+			failed = append(failed, suite)
+		}
+	}
+	impact := &PromotionImpact{
+		RulesetID:   ruleset.GetID(),
+		Ruleset:     ruleset.Name,
+		Enforcement: string(ruleset.Enforcement),
+		RuleSuites:  len(suites),
+		Failed:      len(failed),
+	}
+	if limit > 0 && len(failed) > limit {
+		failed = failed[:limit]
+		impact.Truncated = true
+	}
+	impact.Inspected = len(failed)
+	actors := map[string]int{}
+	refs := map[string]int{}
+	rules := map[string]int{}
+	repositories := map[string]int{}
+	for _, summary := range failed {
+		suite, err := getRuleSuiteDetail(ctx, g, repo, summary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rule suite %d: %w", valueOf(summary.ID), err)
+		}
+		blocked := false
+		for _, evaluation := range suite.RuleEvaluations {
+			if evaluation.RuleSource == nil || valueOf(evaluation.RuleSource.ID) != ruleset.GetID() {
+				continue
+			}
+			if valueOf(evaluation.Result) != RuleSuiteResultFail {
+				continue
+			}
+			blocked = true
+			rules[valueOf(evaluation.RuleType)]++
+		}
+		if !blocked {
+			continue
+		}
+		impact.Blocked++
+		actors[valueOf(suite.ActorName)]++
+		refs[valueOf(suite.Ref)]++
+		if repo.Name == "" {
+			repositories[valueOf(suite.RepositoryName)]++
+		}
+	}
+	impact.Actors = impactCounts(actors)
+	impact.Refs = impactCounts(refs)
+	impact.Rules = impactCounts(rules)
+	impact.Repositories = impactCounts(repositories)
+	return impact, nil
+}
+
+// impactCounts returns counts ordered by the number of blocked pushes, most first, then by key
+func impactCounts(counts map[string]int) []*ImpactCount {
+	result := make([]*ImpactCount, 0, len(counts))
+	for key, blocked := range counts {
+		result = append(result, &ImpactCount{Key: key, Blocked: blocked})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Blocked != result[j].Blocked {
+			return result[i].Blocked > result[j].Blocked
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// PromoteRuleset switches the enforcement of a ruleset of a repository or organization (organization when repo.Name
+// is empty) to active. The ruleset is backed up first.
+func PromoteRuleset(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, ruleset *github.RepositoryRuleset) (*github.RepositoryRuleset, error) {
+	return newRulesetAPI(g, repo, false).setEnforcement(ctx, ruleset.GetID(), string(github.RulesetEnforcementActive), "promote")
+}
@@ -0,0 +1,166 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// ProtectionConversion is a ruleset converted from the classic branch protection of a branch.
+// Unsupported lists the protection settings that have no ruleset equivalent and were not converted.
+type ProtectionConversion struct {
+	Branch      string                      `json:"branch"`
+	Config      *gh.RepositoryRulesetConfig `json:"config"`
+	Unsupported []string                    `json:"unsupported,omitempty"`
+}
+
+// ListProtectedBranches returns the names of the branches of a repository that have classic branch protection
+func ListProtectedBranches(ctx context.Context, g *gh.GitHubClient, repo repository.Repository) ([]string, error) {
+	protected := true
+	branches, err := g.ListBranches(ctx, repo.Owner, repo.Name, &protected)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		names = append(names, branch.GetName())
+	}
+	return names, nil
+}
+
+// ConvertBranchProtections reads the classic branch protection of each branch and converts it to a ruleset.
+// When branches is empty, every protected branch of the repository is converted.
+func ConvertBranchProtections(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, branches []string) ([]*ProtectionConversion, error) {
+	if len(branches) == 0 {
+		var err error
+		branches, err = ListProtectedBranches(ctx, g, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list protected branches: %w", err)
+		}
+	}
+	conversions := make([]*ProtectionConversion, 0, len(branches))
+	for _, branch := range branches {
+		protection, _, err := g.GetClient().Repositories.GetBranchProtection(ctx, repo.Owner, repo.Name, branch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get branch protection of '%s': %w", branch, err)
+		}
+		conversions = append(conversions, ConvertProtection(branch, protection))
+	}
+	return conversions, nil
+}
+
+// ConvertProtection converts the classic branch protection of a branch to an equivalent active ruleset targeting it.
+// Since classic protection does not apply to administrators unless it is enforced for them, the admin repository role
+// is added as a bypass actor in that case.
+func ConvertProtection(branch string, protection *github.Protection) *ProtectionConversion {
+	target := string(github.RulesetTargetBranch)
+	config := &gh.RepositoryRulesetConfig{
+		Name:        fmt.Sprintf("%s branch protection", branch),
+		Target:      &target,
+		Enforcement: string(github.RulesetEnforcementActive),
+		Conditions: &github.RepositoryRulesetConditions{
+			RefName: &github.RepositoryRulesetRefConditionParameters{
+				Include: []string{refPrefixBranch + branch},
+				Exclude: []string{},
+			},
+		},
+		Rules: &github.RepositoryRulesetRules{},
+	}
+	conversion := &ProtectionConversion{Branch: branch, Config: config}
+	rules := config.Rules
+	resolveConversations := protection.RequiredConversationResolution != nil && protection.RequiredConversationResolution.Enabled
+
+	if reviews := protection.RequiredPullRequestReviews; reviews != nil {
+		rules.PullRequest = &github.PullRequestRuleParameters{
+			AllowedMergeMethods: []github.PullRequestMergeMethod{
+				github.PullRequestMergeMethodMerge,
+				github.PullRequestMergeMethodSquash,
+				github.PullRequestMergeMethodRebase,
+			},
+			DismissStaleReviewsOnPush:      reviews.DismissStaleReviews,
+			RequireCodeOwnerReview:         reviews.RequireCodeOwnerReviews,
+			RequireLastPushApproval:        reviews.RequireLastPushApproval,
+			RequiredApprovingReviewCount:   reviews.RequiredApprovingReviewCount,
+			RequiredReviewThreadResolution: resolveConversations,
+		}
+		if r := reviews.DismissalRestrictions; r != nil && len(r.Users)+len(r.Teams)+len(r.Apps) > 0 {
+			conversion.Unsupported = append(conversion.Unsupported, fmt.Sprintf("dismissal_restrictions: only %s can dismiss reviews", describeActors(r.Users, r.Teams, r.Apps)))
+		}
+		if a := reviews.BypassPullRequestAllowances; a != nil && len(a.Users)+len(a.Teams)+len(a.Apps) > 0 {
+			conversion.Unsupported = append(conversion.Unsupported, fmt.Sprintf("bypass_pull_request_allowances: %s can bypass pull request requirements", describeActors(a.Users, a.Teams, a.Apps)))
+		}
+	} else if resolveConversations {
+		// Conversation resolution is a parameter of the pull request rule
+		rules.PullRequest = &github.PullRequestRuleParameters{
+			AllowedMergeMethods: []github.PullRequestMergeMethod{
+				github.PullRequestMergeMethodMerge,
+				github.PullRequestMergeMethodSquash,
+				github.PullRequestMergeMethodRebase,
+			},
+			RequiredReviewThreadResolution: true,
+		}
+	}
+
+	if checks := protection.RequiredStatusChecks; checks != nil {
+		params := &github.RequiredStatusChecksRuleParameters{
+			RequiredStatusChecks:             []*github.RuleStatusCheck{},
+			StrictRequiredStatusChecksPolicy: checks.Strict,
+		}
+		switch {
+		case checks.Checks != nil:
+			for _, check := range *checks.Checks {
+				status := &github.RuleStatusCheck{Context: check.Context}
+				// -1 allows any app, which is the default of rulesets
+				if check.AppID != nil && *check.AppID > 0 {
+					status.IntegrationID = check.AppID
+				}
+				params.RequiredStatusChecks = append(params.RequiredStatusChecks, status)
+			}
+		case checks.Contexts != nil:
+			for _, context := range *checks.Contexts {
+				params.RequiredStatusChecks = append(params.RequiredStatusChecks, &github.RuleStatusCheck{Context: context})
+			}
+		}
+		rules.RequiredStatusChecks = params
+	}
+
+	if protection.RequireLinearHistory != nil && protection.RequireLinearHistory.Enabled {
+		rules.RequiredLinearHistory = &github.EmptyRuleParameters{}
+	}
+	if protection.RequiredSignatures != nil && protection.RequiredSignatures.GetEnabled() {
+		rules.RequiredSignatures = &github.EmptyRuleParameters{}
+	}
+	if protection.AllowForcePushes == nil || !protection.AllowForcePushes.Enabled {
+		rules.NonFastForward = &github.EmptyRuleParameters{}
+	}
+	if protection.AllowDeletions == nil || !protection.AllowDeletions.Enabled {
+		rules.Deletion = &github.EmptyRuleParameters{}
+	}
+	if protection.LockBranch != nil && protection.LockBranch.GetEnabled() {
+		rules.Update = &github.UpdateRuleParameters{
+			UpdateAllowsFetchAndMerge: protection.AllowForkSyncing != nil && protection.AllowForkSyncing.GetEnabled(),
+		}
+	}
+	if protection.BlockCreations != nil && protection.BlockCreations.GetEnabled() {
+		rules.Creation = &github.EmptyRuleParameters{}
+	}
+	if r := protection.Restrictions; r != nil {
+		conversion.Unsupported = append(conversion.Unsupported, fmt.Sprintf("restrictions: only %s can push", describeActors(r.Users, r.Teams, r.Apps)))
+	}
+
+	if protection.EnforceAdmins == nil || !protection.EnforceAdmins.Enabled {
+		config.BypassActors = []*github.BypassActor{{
+			ActorID:    github.Ptr(builtinRepositoryRoles["admin"]),
+			ActorType:  github.Ptr(github.BypassActorTypeRepositoryRole),
+			BypassMode: github.Ptr(github.BypassModeAlways),
+		}}
+	}
+	return conversion
+}
+
+func describeActors(users []*github.User, teams []*github.Team, apps []*github.App) string {
+	return fmt.Sprintf("%d users, %d teams and %d apps", len(users), len(teams), len(apps))
+}
@@ -0,0 +1,500 @@
+package rulekit
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// DefaultSimulationBranch is the default branch assumed when it is neither given nor read from a repository source
+const DefaultSimulationBranch = "main"
+
+const (
+	refPrefixBranch = "refs/heads/"
+	refPrefixTag    = "refs/tags/"
+)
+
+// RefAction is what a simulated push does to its ref
+type RefAction string
+
+const (
+	RefActionCreate RefAction = "create"
+	RefActionUpdate RefAction = "update"
+	RefActionDelete RefAction = "delete"
+)
+
+// RefActions is the list of ref actions, for use with flags
+var RefActions = []string{string(RefActionCreate), string(RefActionUpdate), string(RefActionDelete)}
+
+// SimulationTargets is the list of event targets, for use with flags
+var SimulationTargets = []string{string(github.RulesetTargetBranch), string(github.RulesetTargetTag), string(github.RulesetTargetPush)}
+
+// ChangedFile is a file changed by a simulated push. Size is -1 when unknown.
+type ChangedFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// SimulationEvent is a hypothetical push that rulesets are evaluated against
+type SimulationEvent struct {
+	Target          string         `json:"target"`
+	Ref             string         `json:"ref,omitempty"`
+	DefaultBranch   string         `json:"default_branch,omitempty"`
+	Repository      string         `json:"repository,omitempty"`
+	Action          RefAction      `json:"action"`
+	Force           bool           `json:"force"`
+	CommitMessages  []string       `json:"commit_messages,omitempty"`
+	AuthorEmails    []string       `json:"author_emails,omitempty"`
+	CommitterEmails []string       `json:"committer_emails,omitempty"`
+	Files           []*ChangedFile `json:"files,omitempty"`
+}
+
+// ParseChangedFile parses a changed file in the format 'PATH[:SIZE]', where SIZE is in bytes
+func ParseChangedFile(spec string) (*ChangedFile, error) {
+	file := &ChangedFile{Path: spec, Size: -1}
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		if size, err := strconv.ParseInt(spec[i+1:], 10, 64); err == nil {
+			file.Path = spec[:i]
+			file.Size = size
+		}
+	}
+	if file.Path == "" {
+		return nil, fmt.Errorf("file path is empty in %q", spec)
+	}
+	return file, nil
+}
+
+// QualifyRef returns the fully qualified ref name of a branch or tag name for the target.
+// Names that already start with 'refs/' are returned as is.
+func QualifyRef(ref string, target string) string {
+	if ref == "" || strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	if target == string(github.RulesetTargetTag) {
+		return refPrefixTag + ref
+	}
+	return refPrefixBranch + ref
+}
+
+// RuleOutcome is the result of a single rule against a simulated event
+type RuleOutcome string
+
+const (
+	// RuleOutcomeBlocked means the rule would reject the event
+	RuleOutcomeBlocked RuleOutcome = "blocked"
+	// RuleOutcomePassed means the rule would accept the event
+	RuleOutcomePassed RuleOutcome = "passed"
+	// RuleOutcomeUnchecked means the rule depends on state that is not simulated, such as reviews or status checks
+	RuleOutcomeUnchecked RuleOutcome = "unchecked"
+)
+
+// RuleResult is the result of a single rule against a simulated event
+type RuleResult struct {
+	Type    string      `json:"type"`
+	Outcome RuleOutcome `json:"outcome"`
+	Reason  string      `json:"reason"`
+}
+
+// Simulation is the result of a ruleset against a simulated event
+type Simulation struct {
+	Name        string        `json:"name"`
+	Source      string        `json:"source"`
+	Enforcement string        `json:"enforcement"`
+	Applies     bool          `json:"applies"`
+	Blocked     bool          `json:"blocked"`
+	Reason      string        `json:"reason"`
+	Rules       []*RuleResult `json:"rules"`
+}
+
+// Simulate evaluates a ruleset against a hypothetical event, following the target, ref name and repository name
+// conditions of GitHub. Push rulesets apply to every event. The event is blocked only by rules of an active ruleset;
+// rules of a ruleset in evaluate mode are reported but not enforced.
+func Simulate(source string, config *gh.RepositoryRulesetConfig, event *SimulationEvent) *Simulation {
+	sim := &Simulation{Name: config.Name, Source: source, Enforcement: config.Enforcement, Rules: []*RuleResult{}}
+
+	target := string(github.RulesetTargetBranch)
+	if config.Target != nil && *config.Target != "" {
+		target = *config.Target
+	}
+	var notes []string
+	switch {
+	case config.Enforcement == string(github.RulesetEnforcementDisabled):
+		sim.Reason = "ruleset is disabled"
+		return sim
+	case target != event.Target && target != string(github.RulesetTargetPush):
+		sim.Reason = fmt.Sprintf("ruleset targets %s, not %s", target, event.Target)
+		return sim
+	}
+
+	if target != string(github.RulesetTargetPush) {
+		matched, reason := matchRefCondition(config.Conditions, event)
+		if !matched {
+			sim.Reason = reason
+			return sim
+		}
+	}
+	if config.Conditions != nil && config.Conditions.RepositoryName != nil {
+		if event.Repository == "" {
+			notes = append(notes, "repository name condition was not evaluated")
+		} else if !matchIncludeExclude(config.Conditions.RepositoryName.Include, config.Conditions.RepositoryName.Exclude, event.Repository, matchNamePattern) {
+			sim.Reason = fmt.Sprintf("repository '%s' does not match the repository name condition", event.Repository)
+			return sim
+		}
+	}
+	if config.Conditions != nil && (config.Conditions.RepositoryID != nil || config.Conditions.RepositoryProperty != nil) {
+		notes = append(notes, "repository ID and property conditions were not evaluated")
+	}
+
+	sim.Applies = true
+	sim.Rules = simulateRules(config.Rules, event)
+	blocked := 0
+	for _, rule := range sim.Rules {
+		if rule.Outcome == RuleOutcomeBlocked {
+			blocked++
+		}
+	}
+	blocking := fmt.Sprintf("%d rules would block the event", blocked)
+	if blocked == 1 {
+		blocking = "1 rule would block the event"
+	}
+	switch {
+	case blocked > 0 && config.Enforcement == string(github.RulesetEnforcementActive):
+		sim.Blocked = true
+		notes = append([]string{blocking}, notes...)
+	case blocked > 0:
+		notes = append([]string{fmt.Sprintf("%s, but the ruleset is in %s mode", blocking, config.Enforcement)}, notes...)
+	default:
+		notes = append([]string{"no rule would block the event"}, notes...)
+	}
+	sim.Reason = strings.Join(notes, "; ")
+	return sim
+}
+
+// IsBlocked reports whether any simulation blocks the event
+func IsBlocked(sims []*Simulation) bool {
+	for _, sim := range sims {
+		if sim.Blocked {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRefCondition matches the ref of the event with the ref name condition of a branch or tag ruleset.
+// A ruleset without a ref name condition does not target any ref.
+func matchRefCondition(conditions *github.RepositoryRulesetConditions, event *SimulationEvent) (bool, string) {
+	if conditions == nil || conditions.RefName == nil {
+		return false, "ruleset has no ref name condition"
+	}
+	match := func(pattern string, ref string) bool {
+		return matchRefPattern(pattern, ref, event.DefaultBranch, event.Target)
+	}
+	if !matchIncludeExclude(conditions.RefName.Include, conditions.RefName.Exclude, event.Ref, match) {
+		return false, fmt.Sprintf("ref '%s' does not match the ref name condition", event.Ref)
+	}
+	return true, ""
+}
+
+func matchIncludeExclude(include, exclude []string, name string, match func(pattern, name string) bool) bool {
+	included := false
+	for _, pattern := range include {
+		if match(pattern, name) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, pattern := range exclude {
+		if match(pattern, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRefPattern matches a fully qualified ref with a ref name condition pattern.
+// ~DEFAULT_BRANCH matches the default branch and ~ALL matches every ref of the target.
+// Patterns without the 'refs/' prefix are qualified for the target like the ref.
+func matchRefPattern(pattern string, ref string, defaultBranch string, target string) bool {
+	switch pattern {
+	case "~ALL":
+		return true
+	case "~DEFAULT_BRANCH":
+		return defaultBranch != "" && ref == refPrefixBranch+defaultBranch
+	}
+	return matchFnmatch(QualifyRef(pattern, target), ref)
+}
+
+func matchNamePattern(pattern string, name string) bool {
+	if pattern == "~ALL" {
+		return true
+	}
+	return matchFnmatch(pattern, name)
+}
+
+// fnmatchRegexp converts an fnmatch pattern to a regular expression.
+// '*' and '?' do not match '/', '**' matches any string and '**/' matches any number of directories.
+func fnmatchRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, path.ErrBadPattern
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func matchFnmatch(pattern string, name string) bool {
+	re, err := fnmatchRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(name)
+}
+
+func simulateRules(rules *github.RepositoryRulesetRules, event *SimulationEvent) []*RuleResult {
+	results := []*RuleResult{}
+	if rules == nil {
+		return results
+	}
+	add := func(ruleType github.RepositoryRuleType, outcome RuleOutcome, format string, args ...any) {
+		results = append(results, &RuleResult{Type: string(ruleType), Outcome: outcome, Reason: fmt.Sprintf(format, args...)})
+	}
+	refAction := func(ruleType github.RepositoryRuleType, action RefAction) {
+		if event.Action == action {
+			add(ruleType, RuleOutcomeBlocked, "%s of the ref is restricted", action)
+		} else {
+			add(ruleType, RuleOutcomePassed, "the event does not %s the ref", action)
+		}
+	}
+	unchecked := func(ruleType github.RepositoryRuleType, format string, args ...any) {
+		add(ruleType, RuleOutcomeUnchecked, format, args...)
+	}
+
+	if rules.Creation != nil {
+		refAction(github.RulesetRuleTypeCreation, RefActionCreate)
+	}
+	if rules.Update != nil {
+		refAction(github.RulesetRuleTypeUpdate, RefActionUpdate)
+	}
+	if rules.Deletion != nil {
+		refAction(github.RulesetRuleTypeDeletion, RefActionDelete)
+	}
+	if rules.NonFastForward != nil {
+		if event.Force {
+			add(github.RulesetRuleTypeNonFastForward, RuleOutcomeBlocked, "force pushes are blocked")
+		} else {
+			add(github.RulesetRuleTypeNonFastForward, RuleOutcomePassed, "the event is not a force push")
+		}
+	}
+	if rules.RequiredLinearHistory != nil {
+		unchecked(github.RulesetRuleTypeRequiredLinearHistory, "merge commits are not allowed")
+	}
+	if rules.RequiredSignatures != nil {
+		unchecked(github.RulesetRuleTypeRequiredSignatures, "commits must have verified signatures")
+	}
+	// Pushes that update the ref directly are rejected, while creating and deleting the ref are not pull request changes
+	if rules.PullRequest != nil {
+		if event.Action == RefActionUpdate {
+			add(github.RulesetRuleTypePullRequest, RuleOutcomeBlocked, "changes must be made through a pull request with %d approving reviews", rules.PullRequest.RequiredApprovingReviewCount)
+		} else {
+			add(github.RulesetRuleTypePullRequest, RuleOutcomePassed, "the event does not update the ref")
+		}
+	}
+	if rules.RequiredStatusChecks != nil {
+		contexts := make([]string, 0, len(rules.RequiredStatusChecks.RequiredStatusChecks))
+		for _, check := range rules.RequiredStatusChecks.RequiredStatusChecks {
+			contexts = append(contexts, check.Context)
+		}
+		unchecked(github.RulesetRuleTypeRequiredStatusChecks, "status checks must pass: %s", strings.Join(contexts, ", "))
+	}
+	if rules.RequiredDeployments != nil {
+		unchecked(github.RulesetRuleTypeRequiredDeployments, "deployments must succeed: %s", strings.Join(rules.RequiredDeployments.RequiredDeploymentEnvironments, ", "))
+	}
+	if rules.MergeQueue != nil {
+		if event.Action == RefActionUpdate {
+			add(github.RulesetRuleTypeMergeQueue, RuleOutcomeBlocked, "changes must be merged through a merge queue")
+		} else {
+			add(github.RulesetRuleTypeMergeQueue, RuleOutcomePassed, "the event does not update the ref")
+		}
+	}
+	if rules.Workflows != nil {
+		unchecked(github.RulesetRuleTypeWorkflows, "%d workflows must pass", len(rules.Workflows.Workflows))
+	}
+	if rules.CodeScanning != nil {
+		unchecked(github.RulesetRuleTypeCodeScanning, "code scanning results must meet the thresholds")
+	}
+
+	patternRule := func(ruleType github.RepositoryRuleType, params *github.PatternRuleParameters, subject string, values []string) {
+		if params == nil {
+			return
+		}
+		if event.Action == RefActionDelete || len(values) == 0 {
+			add(ruleType, RuleOutcomePassed, "no %s to check", subject)
+			return
+		}
+		for _, value := range values {
+			ok, err := matchPatternRule(params, value)
+			if err != nil {
+				add(ruleType, RuleOutcomeBlocked, "invalid pattern: %s", err)
+				return
+			}
+			if !ok {
+				add(ruleType, RuleOutcomeBlocked, "%s '%s' %s", subject, value, describePatternRule(params))
+				return
+			}
+		}
+		add(ruleType, RuleOutcomePassed, "every %s %s", subject, describePatternRule(params))
+	}
+	patternRule(github.RulesetRuleTypeCommitMessagePattern, rules.CommitMessagePattern, "commit message", event.CommitMessages)
+	patternRule(github.RulesetRuleTypeCommitAuthorEmailPattern, rules.CommitAuthorEmailPattern, "author email", event.AuthorEmails)
+	patternRule(github.RulesetRuleTypeCommitterEmailPattern, rules.CommitterEmailPattern, "committer email", event.CommitterEmails)
+	var refNames []string
+	if event.Action == RefActionCreate || event.Action == RefActionUpdate {
+		refNames = []string{strings.TrimPrefix(strings.TrimPrefix(event.Ref, refPrefixBranch), refPrefixTag)}
+	}
+	if strings.HasPrefix(event.Ref, refPrefixBranch) {
+		patternRule(github.RulesetRuleTypeBranchNamePattern, rules.BranchNamePattern, "branch name", refNames)
+	} else {
+		patternRule(github.RulesetRuleTypeBranchNamePattern, rules.BranchNamePattern, "branch name", nil)
+	}
+	if strings.HasPrefix(event.Ref, refPrefixTag) {
+		patternRule(github.RulesetRuleTypeTagNamePattern, rules.TagNamePattern, "tag name", refNames)
+	} else {
+		patternRule(github.RulesetRuleTypeTagNamePattern, rules.TagNamePattern, "tag name", nil)
+	}
+
+	fileRule := func(ruleType github.RepositoryRuleType, check func(file *ChangedFile) string) {
+		for _, file := range event.Files {
+			if reason := check(file); reason != "" {
+				add(ruleType, RuleOutcomeBlocked, "%s", reason)
+				return
+			}
+		}
+		add(ruleType, RuleOutcomePassed, "no changed file is restricted")
+	}
+	if rules.FilePathRestriction != nil {
+		fileRule(github.RulesetRuleTypeFilePathRestriction, func(file *ChangedFile) string {
+			for _, pattern := range rules.FilePathRestriction.RestrictedFilePaths {
+				if matchFnmatch(pattern, file.Path) {
+					return fmt.Sprintf("'%s' matches the restricted path '%s'", file.Path, pattern)
+				}
+			}
+			return ""
+		})
+	}
+	if rules.MaxFilePathLength != nil {
+		fileRule(github.RulesetRuleTypeMaxFilePathLength, func(file *ChangedFile) string {
+			if len(file.Path) > rules.MaxFilePathLength.MaxFilePathLength {
+				return fmt.Sprintf("'%s' is longer than %d characters", file.Path, rules.MaxFilePathLength.MaxFilePathLength)
+			}
+			return ""
+		})
+	}
+	if rules.FileExtensionRestriction != nil {
+		fileRule(github.RulesetRuleTypeFileExtensionRestriction, func(file *ChangedFile) string {
+			for _, extension := range rules.FileExtensionRestriction.RestrictedFileExtensions {
+				if matchFileExtension(extension, file.Path) {
+					return fmt.Sprintf("'%s' has the restricted extension '%s'", file.Path, extension)
+				}
+			}
+			return ""
+		})
+	}
+	if rules.MaxFileSize != nil {
+		// max_file_size is in megabytes
+		limit := rules.MaxFileSize.MaxFileSize * 1024 * 1024
+		fileRule(github.RulesetRuleTypeMaxFileSize, func(file *ChangedFile) string {
+			if file.Size > limit {
+				return fmt.Sprintf("'%s' is larger than %d MB", file.Path, rules.MaxFileSize.MaxFileSize)
+			}
+			return ""
+		})
+	}
+	return results
+}
+
+// matchPatternRule reports whether a value satisfies a metadata pattern rule
+func matchPatternRule(params *github.PatternRuleParameters, value string) (bool, error) {
+	var matched bool
+	switch params.Operator {
+	case github.PatternRuleOperatorStartsWith:
+		matched = strings.HasPrefix(value, params.Pattern)
+	case github.PatternRuleOperatorEndsWith:
+		matched = strings.HasSuffix(value, params.Pattern)
+	case github.PatternRuleOperatorContains:
+		matched = strings.Contains(value, params.Pattern)
+	case github.PatternRuleOperatorRegex:
+		re, err := regexp.Compile(params.Pattern)
+		if err != nil {
+			return false, err
+		}
+		matched = re.MatchString(value)
+	default:
+		return false, fmt.Errorf("unknown operator '%s'", params.Operator)
+	}
+	if params.Negate != nil && *params.Negate {
+		return !matched, nil
+	}
+	return matched, nil
+}
+
+var patternRuleOperatorVerbs = map[github.PatternRuleOperator]string{
+	github.PatternRuleOperatorStartsWith: "start with",
+	github.PatternRuleOperatorEndsWith:   "end with",
+	github.PatternRuleOperatorContains:   "contain",
+	github.PatternRuleOperatorRegex:      "match",
+}
+
+func describePatternRule(params *github.PatternRuleParameters) string {
+	verb := "must"
+	if params.Negate != nil && *params.Negate {
+		verb = "must not"
+	}
+	return fmt.Sprintf("%s %s '%s'", verb, patternRuleOperatorVerbs[params.Operator], params.Pattern)
+}
+
+// matchFileExtension matches a file path with a restricted file extension such as '*.exe' or '.exe'
+func matchFileExtension(extension string, filePath string) bool {
+	name := path.Base(filePath)
+	if strings.ContainsAny(extension, "*?[") {
+		return matchFnmatch(extension, name)
+	}
+	return strings.HasSuffix(name, "."+strings.TrimPrefix(extension, "."))
+}
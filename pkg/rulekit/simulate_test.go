@@ -0,0 +1,286 @@
+package rulekit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v79/github"
+)
+
+func TestMatchFnmatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "main", name: "main", want: true},
+		{pattern: "main", name: "main2", want: false},
+		{pattern: "release/*", name: "release/v1", want: true},
+		{pattern: "release/*", name: "release/v1/hotfix", want: false},
+		{pattern: "release/**", name: "release/v1/hotfix", want: true},
+		{pattern: "**/secrets/*", name: "secrets/key", want: true},
+		{pattern: "**/secrets/*", name: "a/b/secrets/key", want: true},
+		{pattern: "v?", name: "v1", want: true},
+		{pattern: "v?", name: "v/", want: false},
+		{pattern: "v[0-9]", name: "v7", want: true},
+		{pattern: "v[!0-9]", name: "v7", want: false},
+		{pattern: "v[!0-9]", name: "vx", want: true},
+		{pattern: "a.b", name: "axb", want: false},
+		{pattern: "v[0-9", name: "v1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"~"+tt.name, func(t *testing.T) {
+			if got := matchFnmatch(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("matchFnmatch(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFnmatchRegexpRejectsUnclosedClass(t *testing.T) {
+	if _, err := fnmatchRegexp("refs/heads/[main"); err == nil {
+		t.Error("fnmatchRegexp() error = nil, want an error")
+	}
+}
+
+func TestMatchRefPattern(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern       string
+		ref           string
+		defaultBranch string
+		target        string
+		want          bool
+	}{
+		{name: "all", pattern: "~ALL", ref: "refs/heads/any", target: "branch", want: true},
+		{name: "default branch", pattern: "~DEFAULT_BRANCH", ref: "refs/heads/main", defaultBranch: "main", target: "branch", want: true},
+		{name: "other branch than default", pattern: "~DEFAULT_BRANCH", ref: "refs/heads/dev", defaultBranch: "main", target: "branch", want: false},
+		{name: "unknown default branch", pattern: "~DEFAULT_BRANCH", ref: "refs/heads/main", target: "branch", want: false},
+		{name: "qualified pattern", pattern: "refs/heads/release/*", ref: "refs/heads/release/v1", target: "branch", want: true},
+		{name: "branch name pattern", pattern: "release/*", ref: "refs/heads/release/v1", target: "branch", want: true},
+		{name: "tag name pattern", pattern: "v*", ref: "refs/tags/v1", target: "tag", want: true},
+		{name: "branch pattern does not match tags", pattern: "refs/heads/v*", ref: "refs/tags/v1", target: "tag", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchRefPattern(tt.pattern, tt.ref, tt.defaultBranch, tt.target); got != tt.want {
+				t.Errorf("matchRefPattern(%q, %q) = %v, want %v", tt.pattern, tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchRefCondition(t *testing.T) {
+	conditions := &github.RepositoryRulesetConditions{
+		RefName: &github.RepositoryRulesetRefConditionParameters{
+			Include: []string{"~DEFAULT_BRANCH", "refs/heads/release/*"},
+			Exclude: []string{"refs/heads/release/old"},
+		},
+	}
+	tests := []struct {
+		name       string
+		conditions *github.RepositoryRulesetConditions
+		ref        string
+		want       bool
+	}{
+		{name: "included", conditions: conditions, ref: "refs/heads/main", want: true},
+		{name: "included by pattern", conditions: conditions, ref: "refs/heads/release/v1", want: true},
+		{name: "excluded", conditions: conditions, ref: "refs/heads/release/old", want: false},
+		{name: "not included", conditions: conditions, ref: "refs/heads/dev", want: false},
+		{name: "no ref name condition", conditions: &github.RepositoryRulesetConditions{}, ref: "refs/heads/main", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &SimulationEvent{Target: "branch", Ref: tt.ref, DefaultBranch: "main"}
+			if got, _ := matchRefCondition(tt.conditions, event); got != tt.want {
+				t.Errorf("matchRefCondition(%q) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPatternRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		operator github.PatternRuleOperator
+		pattern  string
+		negate   bool
+		value    string
+		want     bool
+		wantErr  bool
+	}{
+		{name: "starts with", operator: github.PatternRuleOperatorStartsWith, pattern: "feat:", value: "feat: add", want: true},
+		{name: "ends with", operator: github.PatternRuleOperatorEndsWith, pattern: "@example.com", value: "a@example.org", want: false},
+		{name: "contains", operator: github.PatternRuleOperatorContains, pattern: "WIP", value: "fix WIP", want: true},
+		{name: "negated contains", operator: github.PatternRuleOperatorContains, pattern: "WIP", negate: true, value: "fix WIP", want: false},
+		{name: "regex", operator: github.PatternRuleOperatorRegex, pattern: `^(feat|fix)(\(.+\))?: `, value: "fix(cli): typo", want: true},
+		{name: "invalid regex", operator: github.PatternRuleOperatorRegex, pattern: "(", value: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &github.PatternRuleParameters{Operator: tt.operator, Pattern: tt.pattern, Negate: github.Ptr(tt.negate)}
+			got, err := matchPatternRule(params, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchPatternRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("matchPatternRule(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchFileExtension(t *testing.T) {
+	tests := []struct {
+		extension string
+		path      string
+		want      bool
+	}{
+		{extension: ".exe", path: "bin/tool.exe", want: true},
+		{extension: "exe", path: "bin/tool.exe", want: true},
+		{extension: "*.exe", path: "bin/tool.exe", want: true},
+		{extension: ".exe", path: "bin/tool.exe.txt", want: false},
+		{extension: ".exe", path: "exe/tool", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.extension+"~"+tt.path, func(t *testing.T) {
+			if got := matchFileExtension(tt.extension, tt.path); got != tt.want {
+				t.Errorf("matchFileExtension(%q, %q) = %v, want %v", tt.extension, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQualifyRef(t *testing.T) {
+	tests := []struct {
+		ref    string
+		target string
+		want   string
+	}{
+		{ref: "main", target: "branch", want: "refs/heads/main"},
+		{ref: "v1", target: "tag", want: "refs/tags/v1"},
+		{ref: "refs/pull/1/head", target: "branch", want: "refs/pull/1/head"},
+		{ref: "", target: "push", want: ""},
+	}
+	for _, tt := range tests {
+		if got := QualifyRef(tt.ref, tt.target); got != tt.want {
+			t.Errorf("QualifyRef(%q, %q) = %q, want %q", tt.ref, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestParseChangedFile(t *testing.T) {
+	tests := []struct {
+		spec     string
+		wantPath string
+		wantSize int64
+		wantErr  bool
+	}{
+		{spec: "big.bin:1048576", wantPath: "big.bin", wantSize: 1048576},
+		{spec: "src/main.go", wantPath: "src/main.go", wantSize: -1},
+		{spec: "c:drive", wantPath: "c:drive", wantSize: -1},
+		{spec: ":10", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			file, err := ParseChangedFile(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseChangedFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if file.Path != tt.wantPath || file.Size != tt.wantSize {
+				t.Errorf("ParseChangedFile() = %s:%d, want %s:%d", file.Path, file.Size, tt.wantPath, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	ruleset := `{"name":"main","target":"branch","enforcement":"%s","conditions":{"ref_name":{"include":["~DEFAULT_BRANCH"],"exclude":[]}},"rules":%s}`
+	tests := []struct {
+		name        string
+		enforcement string
+		rules       string
+		event       SimulationEvent
+		wantApplies bool
+		wantBlocked bool
+		wantReason  string
+	}{
+		{
+			name:        "force push to the default branch",
+			enforcement: "active",
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/main", DefaultBranch: "main", Action: RefActionUpdate, Force: true},
+			wantApplies: true,
+			wantBlocked: true,
+		},
+		{
+			name:        "fast-forward push to the default branch",
+			enforcement: "active",
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/main", DefaultBranch: "main", Action: RefActionUpdate},
+			wantApplies: true,
+		},
+		{
+			name:        "force push in evaluate mode",
+			enforcement: "evaluate",
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/main", DefaultBranch: "main", Action: RefActionUpdate, Force: true},
+			wantApplies: true,
+		},
+		{
+			name:        "force push to another branch",
+			enforcement: "active",
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/dev", DefaultBranch: "main", Action: RefActionUpdate, Force: true},
+		},
+		{
+			name:        "tag event",
+			enforcement: "active",
+			event:       SimulationEvent{Target: "tag", Ref: "refs/tags/v1", DefaultBranch: "main", Action: RefActionDelete},
+		},
+		{
+			name:        "direct update of a branch that requires pull requests",
+			enforcement: "active",
+			rules:       `[{"type":"pull_request","parameters":{"dismiss_stale_reviews_on_push":false,"require_code_owner_review":false,"require_last_push_approval":false,"required_approving_review_count":1,"required_review_thread_resolution":false}}]`,
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/main", DefaultBranch: "main", Action: RefActionUpdate},
+			wantApplies: true,
+			wantBlocked: true,
+			wantReason:  "1 rule would block the event",
+		},
+		{
+			name:        "creation of a branch that requires pull requests",
+			enforcement: "active",
+			rules:       `[{"type":"pull_request","parameters":{"dismiss_stale_reviews_on_push":false,"require_code_owner_review":false,"require_last_push_approval":false,"required_approving_review_count":1,"required_review_thread_resolution":false}}]`,
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/main", DefaultBranch: "main", Action: RefActionCreate},
+			wantApplies: true,
+		},
+		{
+			name:        "direct update of a branch that requires a merge queue",
+			enforcement: "active",
+			rules:       `[{"type":"merge_queue","parameters":{"check_response_timeout_minutes":60,"grouping_strategy":"ALLGREEN","max_entries_to_build":5,"max_entries_to_merge":5,"merge_method":"MERGE","min_entries_to_merge":1,"min_entries_to_merge_wait_minutes":5}}]`,
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/main", DefaultBranch: "main", Action: RefActionUpdate},
+			wantApplies: true,
+			wantBlocked: true,
+		},
+		{
+			name:        "disabled ruleset",
+			enforcement: "disabled",
+			event:       SimulationEvent{Target: "branch", Ref: "refs/heads/main", DefaultBranch: "main", Action: RefActionDelete},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if rules == "" {
+				rules = `[{"type":"non_fast_forward"},{"type":"deletion"}]`
+			}
+			config := mustConfig(t, fmt.Sprintf(ruleset, tt.enforcement, rules))
+			sim := Simulate("ruleset.json", config, &tt.event)
+			if sim.Applies != tt.wantApplies || sim.Blocked != tt.wantBlocked {
+				t.Errorf("Simulate() applies = %v, blocked = %v, want %v, %v (%s)", sim.Applies, sim.Blocked, tt.wantApplies, tt.wantBlocked, sim.Reason)
+			}
+			if tt.wantReason != "" && sim.Reason != tt.wantReason {
+				t.Errorf("Simulate() reason = %q, want %q", sim.Reason, tt.wantReason)
+			}
+		})
+	}
+}
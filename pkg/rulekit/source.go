@@ -0,0 +1,116 @@
+package rulekit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+// SourceKind is where a ruleset is read from
+type SourceKind string
+
+const (
+	SourceKindFile SourceKind = "file"
+	SourceKindRepo SourceKind = "repo"
+	SourceKindOrg  SourceKind = "org"
+)
+
+const (
+	sourceRepoPrefix = "repo:"
+	sourceOrgPrefix  = "org:"
+)
+
+// Source identifies a ruleset in a local file, a repository or an organization
+type Source struct {
+	Kind       SourceKind
+	Path       string
+	Repository repository.Repository
+	Ruleset    string
+}
+
+// ParseSource parses a ruleset source specification.
+// Accepted formats are a file path ('-' for stdin), 'repo:[HOST/]OWNER/REPO#ID-OR-NAME' and 'org:[HOST/]OWNER#ID-OR-NAME'.
+func ParseSource(spec string) (*Source, error) {
+	var kind SourceKind
+	var target string
+	switch {
+	case strings.HasPrefix(spec, sourceRepoPrefix):
+		kind = SourceKindRepo
+		target = strings.TrimPrefix(spec, sourceRepoPrefix)
+	case strings.HasPrefix(spec, sourceOrgPrefix):
+		kind = SourceKindOrg
+		target = strings.TrimPrefix(spec, sourceOrgPrefix)
+	default:
+		return &Source{Kind: SourceKindFile, Path: spec}, nil
+	}
+
+	location, ruleset, ok := strings.Cut(target, "#")
+	if !ok || ruleset == "" {
+		return nil, fmt.Errorf("ruleset ID or name is required after '#' in %q", spec)
+	}
+
+	var repo repository.Repository
+	var err error
+	if kind == SourceKindRepo {
+		repo, err = parser.Repository(parser.RepositoryInput(location))
+	} else {
+		repo, err = parser.Repository(parser.RepositoryOwnerWithHost(location))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Source{Kind: kind, Repository: repo, Ruleset: ruleset}, nil
+}
+
+// String returns the display name of the source
+func (s *Source) String() string {
+	switch s.Kind {
+	case SourceKindRepo:
+		return fmt.Sprintf("%s%s#%s", sourceRepoPrefix, parser.GetRepositoryFullNameWithHost(s.Repository), s.Ruleset)
+	case SourceKindOrg:
+		owner := s.Repository.Owner
+		if s.Repository.Host != "" {
+			owner = s.Repository.Host + "/" + owner
+		}
+		return fmt.Sprintf("%s%s#%s", sourceOrgPrefix, owner, s.Ruleset)
+	default:
+		return s.Path
+	}
+}
+
+// Load reads the ruleset of the source in the export format
+func (s *Source) Load(ctx context.Context) (*gh.RepositoryRulesetConfig, error) {
+	if s.Kind == SourceKindFile {
+		return LoadConfig(s.Path)
+	}
+
+	client, err := gh.NewGitHubClientWithRepo(s.Repository)
+	if err != nil {
+		return nil, err
+	}
+	ruleset, err := FindRuleset(ctx, client, s.Repository, s.Ruleset)
+	if err != nil {
+		return nil, err
+	}
+	return gh.ExportRuleset(ruleset), nil
+}
+
+// DefaultBranch returns the default branch of the repository of a repo source, or an empty string for other sources
+func (s *Source) DefaultBranch(ctx context.Context) (string, error) {
+	if s.Kind != SourceKindRepo {
+		return "", nil
+	}
+	client, err := gh.NewGitHubClientWithRepo(s.Repository)
+	if err != nil {
+		return "", err
+	}
+	repo, err := gh.GetRepository(ctx, client, s.Repository)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository: %w", err)
+	}
+	return repo.GetDefaultBranch(), nil
+}
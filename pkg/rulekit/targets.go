@@ -0,0 +1,156 @@
+package rulekit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/google/go-github/v79/github"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+)
+
+// RepositoryQuery selects repositories of an organization by topic, custom property and name.
+// Every given criterion must match; archived repositories are never selected.
+type RepositoryQuery struct {
+	Topics      []string
+	Properties  map[string]string
+	NamePattern string
+}
+
+// IsEmpty reports whether the query has no criteria
+func (q *RepositoryQuery) IsEmpty() bool {
+	return len(q.Topics) == 0 && len(q.Properties) == 0 && q.NamePattern == ""
+}
+
+// ParsePropertyFilters parses custom property filters in the format 'name=value'
+func ParsePropertyFilters(filters []string) (map[string]string, error) {
+	properties := make(map[string]string, len(filters))
+	for _, filter := range filters {
+		name, value, ok := strings.Cut(filter, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid property filter %q, expected name=value", filter)
+		}
+		properties[name] = value
+	}
+	return properties, nil
+}
+
+// QueryRepositories returns the repositories of the organization of owner that match the query, sorted by name
+func QueryRepositories(ctx context.Context, g *gh.GitHubClient, owner repository.Repository, query *RepositoryQuery) ([]repository.Repository, error) {
+	if query.NamePattern != "" {
+		if _, err := fnmatchRegexp(query.NamePattern); err != nil {
+			return nil, fmt.Errorf("malformed name pattern '%s'", query.NamePattern)
+		}
+	}
+	repos, err := g.ListOrganizationRepositories(ctx, owner.Owner, "all")
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]map[string]any
+	if len(query.Properties) > 0 {
+		values, err = listCustomPropertyValues(ctx, g, owner.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list custom property values: %w", err)
+		}
+	}
+
+	var result []repository.Repository
+	for _, r := range repos {
+		if r.GetArchived() {
+			continue
+		}
+		if query.NamePattern != "" && !matchFnmatch(query.NamePattern, r.GetName()) {
+			continue
+		}
+		if !hasTopics(r, query.Topics) || !hasProperties(values[r.GetName()], query.Properties) {
+			continue
+		}
+		result = append(result, repository.Repository{Host: owner.Host, Owner: owner.Owner, Name: r.GetName()})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func hasTopics(r *github.Repository, topics []string) bool {
+	for _, topic := range topics {
+		if !slices.Contains(r.Topics, topic) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasProperties reports whether custom property values match the filters. Multi-select values match when they
+// contain the filter value.
+func hasProperties(values map[string]any, filters map[string]string) bool {
+	for name, want := range filters {
+		switch value := values[name].(type) {
+		case string:
+			if value != want {
+				return false
+			}
+		case []string:
+			if !slices.Contains(value, want) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// listCustomPropertyValues returns the custom property values of every repository of an organization, keyed by repository name
+func listCustomPropertyValues(ctx context.Context, g *gh.GitHubClient, org string) (map[string]map[string]any, error) {
+	values := map[string]map[string]any{}
+	opts := &github.ListCustomPropertyValuesOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		repos, resp, err := g.GetClient().Organizations.ListCustomPropertyValues(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			properties := make(map[string]any, len(repo.Properties))
+			for _, property := range repo.Properties {
+				properties[property.PropertyName] = property.Value
+			}
+			values[repo.RepositoryName] = properties
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return values, nil
+}
+
+// ReadRepositoryList reads repository names from a file, or stdin when path is '-', one per line.
+// Blank lines and lines starting with '#' are ignored.
+func ReadRepositoryList(path string) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = readStdin()
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, scanner.Err()
+}
@@ -0,0 +1,155 @@
+package rulekit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/parser"
+)
+
+// TemplateRepository is the repository metadata available to ruleset file templates as .Repo
+type TemplateRepository struct {
+	Host          string
+	Owner         string
+	Name          string
+	FullName      string
+	DefaultBranch string
+	Visibility    string
+	Private       bool
+	Archived      bool
+	Topics        []string
+}
+
+// TemplateData is the data ruleset files are rendered with when they contain template actions.
+// Repository metadata is fetched on first use, so files without template actions cost no extra request.
+type TemplateData struct {
+	Owner string
+	Vars  map[string]any
+
+	ctx    context.Context
+	client *gh.GitHubClient
+	target repository.Repository
+	repo   *TemplateRepository
+}
+
+// NewTemplateData creates the template data for a target repository, organization (repo.Name is empty) or enterprise.
+// g may be nil when ruleset files are rendered offline, without repository metadata.
+func NewTemplateData(ctx context.Context, g *gh.GitHubClient, repo repository.Repository, vars map[string]any) *TemplateData {
+	if vars == nil {
+		vars = map[string]any{}
+	}
+	return &TemplateData{Owner: repo.Owner, Vars: vars, ctx: ctx, client: g, target: repo}
+}
+
+// Repo returns the metadata of the target repository. It fails when the target is not a repository or there is no client.
+func (d *TemplateData) Repo() (*TemplateRepository, error) {
+	if d.repo != nil {
+		return d.repo, nil
+	}
+	if d.client == nil || d.target.Name == "" {
+		return nil, fmt.Errorf("repository metadata is not available without a target repository")
+	}
+	r, err := gh.GetRepository(d.ctx, d.client, d.target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository metadata: %w", err)
+	}
+	d.repo = &TemplateRepository{
+		Host:          d.target.Host,
+		Owner:         d.target.Owner,
+		Name:          d.target.Name,
+		FullName:      parser.GetRepositoryFullName(d.target),
+		DefaultBranch: r.GetDefaultBranch(),
+		Visibility:    r.GetVisibility(),
+		Private:       r.GetPrivate(),
+		Archived:      r.GetArchived(),
+		Topics:        r.Topics,
+	}
+	return d.repo, nil
+}
+
+// DefaultBranch returns the default branch of the target repository
+func (d *TemplateData) DefaultBranch() (string, error) {
+	repo, err := d.Repo()
+	if err != nil {
+		return "", err
+	}
+	return repo.DefaultBranch, nil
+}
+
+// LoadTemplateVars reads template variables from a JSON or YAML vars file and 'key=value' pairs.
+// Pairs take precedence over the vars file.
+func LoadTemplateVars(varsFile string, pairs []string) (map[string]any, error) {
+	vars := map[string]any{}
+	if varsFile != "" {
+		data, err := os.ReadFile(varsFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := unmarshalVars(data, vars); err != nil {
+			return nil, fmt.Errorf("%s: %w", varsFile, err)
+		}
+	}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable %q, expected key=value", pair)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// unmarshalVars parses a vars file, which is a single JSON or YAML object
+func unmarshalVars(data []byte, vars map[string]any) error {
+	parsed, err := parseGenericDocuments(data, DetectFormat(data))
+	if err != nil {
+		return err
+	}
+	if len(parsed) != 1 {
+		return fmt.Errorf("expected a single document, found %d", len(parsed))
+	}
+	m, ok := parsed[0].(map[string]any)
+	if !ok {
+		return fmt.Errorf("variables must be an object, got %T", parsed[0])
+	}
+	for k, v := range m {
+		vars[k] = v
+	}
+	return nil
+}
+
+// hasTemplateActions reports whether data contains a template action
+func hasTemplateActions(data []byte) bool {
+	return bytes.Contains(data, []byte("{{"))
+}
+
+// RenderTemplate renders ruleset file data as a Go template. Data without template actions is returned as is.
+// Besides the fields of TemplateData, the 'var' function returns a variable and fails when it is not defined.
+func RenderTemplate(name string, data []byte, td *TemplateData) ([]byte, error) {
+	if td == nil || !hasTemplateActions(data) {
+		return data, nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		"var": func(key string) (any, error) {
+			v, ok := td.Vars[key]
+			if !ok {
+				return nil, fmt.Errorf("template variable '%s' is not defined", key)
+			}
+			return v, nil
+		},
+	}).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, td); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,471 @@
+package rulekit
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationError is a problem found in a ruleset file, located by the JSON path of the offending value
+type ValidationError struct {
+	File    string `json:"file"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.File, e.Path, e.Message)
+}
+
+type valueKind string
+
+const (
+	kindBool        valueKind = "boolean"
+	kindInteger     valueKind = "integer"
+	kindString      valueKind = "string"
+	kindStringList  valueKind = "array of strings"
+	kindIntegerList valueKind = "array of integers"
+	kindObject      valueKind = "object"
+	kindObjectList  valueKind = "array of objects"
+	kindRules       valueKind = "array of rules"
+)
+
+// fieldSpec describes a field of a ruleset file
+type fieldSpec struct {
+	kind     valueKind
+	required bool
+	// values is the set of allowed values of a string field, or of the elements of a string list
+	values []string
+	// fields describes the fields of an object, or of each object of a list
+	fields map[string]fieldSpec
+	// check validates a string, or each element of a string list
+	check func(s string) error
+}
+
+var (
+	refNameFields = map[string]fieldSpec{
+		"include": {kind: kindStringList, check: checkRefPattern},
+		"exclude": {kind: kindStringList, check: checkRefPattern},
+	}
+	namePatternFields = map[string]fieldSpec{
+		"include": {kind: kindStringList, check: checkNamePattern},
+		"exclude": {kind: kindStringList, check: checkNamePattern},
+	}
+	propertyTargetFields = map[string]fieldSpec{
+		"include": {kind: kindObjectList, fields: propertyFields},
+		"exclude": {kind: kindObjectList, fields: propertyFields},
+	}
+	propertyFields = map[string]fieldSpec{
+		"name":            {kind: kindString, required: true},
+		"property_values": {kind: kindStringList, required: true},
+		"source":          {kind: kindString},
+	}
+
+	rulesetFields = map[string]fieldSpec{
+		"id":          {kind: kindInteger},
+		"name":        {kind: kindString, required: true},
+		"target":      {kind: kindString, values: []string{"branch", "tag", "push", "repository"}},
+		"source_type": {kind: kindString, values: []string{"Repository", "Organization", "Enterprise"}},
+		"source":      {kind: kindString},
+		"enforcement": {kind: kindString, required: true, values: []string{"disabled", "active", "evaluate"}},
+		"conditions": {kind: kindObject, fields: map[string]fieldSpec{
+			"ref_name":        {kind: kindObject, fields: refNameFields},
+			"repository_name": {kind: kindObject, fields: withField(namePatternFields, "protected", fieldSpec{kind: kindBool})},
+			"repository_id": {kind: kindObject, fields: map[string]fieldSpec{
+				"repository_ids": {kind: kindIntegerList},
+			}},
+			"repository_property": {kind: kindObject, fields: propertyTargetFields},
+			"organization_name":   {kind: kindObject, fields: namePatternFields},
+			"organization_id": {kind: kindObject, fields: map[string]fieldSpec{
+				"organization_ids": {kind: kindIntegerList},
+			}},
+			"organization_property": {kind: kindObject, fields: propertyTargetFields},
+		}},
+		"rules": {kind: kindRules},
+		"bypass_actors": {kind: kindObjectList, fields: map[string]fieldSpec{
+			"actor_id":    {kind: kindInteger},
+			"actor_type":  {kind: kindString, required: true, values: bypassActorTypes},
+			"bypass_mode": {kind: kindString, values: []string{"always", "pull_request", "exempt", "never"}},
+		}},
+	}
+
+	patternRuleParameters = map[string]fieldSpec{
+		"name":     {kind: kindString},
+		"negate":   {kind: kindBool},
+		"operator": {kind: kindString, required: true, values: []string{"starts_with", "ends_with", "contains", "regex"}},
+		"pattern":  {kind: kindString, required: true},
+	}
+
+	// ruleParameters describes the parameters of each rule type. A nil map means the rule takes no parameters.
+	ruleParameters = map[string]map[string]fieldSpec{
+		"creation":                nil,
+		"deletion":                nil,
+		"non_fast_forward":        nil,
+		"required_linear_history": nil,
+		"required_signatures":     nil,
+		"update": {
+			"update_allows_fetch_and_merge": {kind: kindBool},
+		},
+		"merge_queue": {
+			"check_response_timeout_minutes":    {kind: kindInteger, required: true},
+			"grouping_strategy":                 {kind: kindString, required: true, values: []string{"ALLGREEN", "HEADGREEN"}},
+			"max_entries_to_build":              {kind: kindInteger, required: true},
+			"max_entries_to_merge":              {kind: kindInteger, required: true},
+			"merge_method":                      {kind: kindString, required: true, values: []string{"MERGE", "SQUASH", "REBASE"}},
+			"min_entries_to_merge":              {kind: kindInteger, required: true},
+			"min_entries_to_merge_wait_minutes": {kind: kindInteger, required: true},
+		},
+		"required_deployments": {
+			"required_deployment_environments": {kind: kindStringList, required: true},
+		},
+		"pull_request": {
+			"allowed_merge_methods":                 {kind: kindStringList, values: []string{"merge", "squash", "rebase"}},
+			"automatic_copilot_code_review_enabled": {kind: kindBool},
+			"dismiss_stale_reviews_on_push":         {kind: kindBool, required: true},
+			"require_code_owner_review":             {kind: kindBool, required: true},
+			"require_last_push_approval":            {kind: kindBool, required: true},
+			"required_approving_review_count":       {kind: kindInteger, required: true},
+			"required_review_thread_resolution":     {kind: kindBool, required: true},
+			"required_reviewers": {kind: kindObjectList, fields: map[string]fieldSpec{
+				"minimum_approvals": {kind: kindInteger},
+				"file_patterns":     {kind: kindStringList},
+				"reviewer": {kind: kindObject, fields: map[string]fieldSpec{
+					"id":   {kind: kindInteger},
+					"type": {kind: kindString, values: []string{"Team"}},
+				}},
+			}},
+		},
+		"required_status_checks": {
+			"do_not_enforce_on_create": {kind: kindBool},
+			"required_status_checks": {kind: kindObjectList, required: true, fields: map[string]fieldSpec{
+				"context":        {kind: kindString, required: true},
+				"integration_id": {kind: kindInteger},
+			}},
+			"strict_required_status_checks_policy": {kind: kindBool, required: true},
+		},
+		"commit_message_pattern":      patternRuleParameters,
+		"commit_author_email_pattern": patternRuleParameters,
+		"committer_email_pattern":     patternRuleParameters,
+		"branch_name_pattern":         patternRuleParameters,
+		"tag_name_pattern":            patternRuleParameters,
+		"file_path_restriction": {
+			"restricted_file_paths": {kind: kindStringList, required: true},
+		},
+		"max_file_path_length": {
+			"max_file_path_length": {kind: kindInteger, required: true},
+		},
+		"file_extension_restriction": {
+			"restricted_file_extensions": {kind: kindStringList, required: true},
+		},
+		"max_file_size": {
+			"max_file_size": {kind: kindInteger, required: true},
+		},
+		"workflows": {
+			"do_not_enforce_on_create": {kind: kindBool},
+			"workflows": {kind: kindObjectList, required: true, fields: map[string]fieldSpec{
+				"path":          {kind: kindString, required: true},
+				"ref":           {kind: kindString},
+				"repository_id": {kind: kindInteger, required: true},
+				"sha":           {kind: kindString},
+			}},
+		},
+		"code_scanning": {
+			"code_scanning_tools": {kind: kindObjectList, required: true, fields: map[string]fieldSpec{
+				"alerts_threshold":          {kind: kindString, required: true, values: []string{"none", "errors", "errors_and_warnings", "all"}},
+				"security_alerts_threshold": {kind: kindString, required: true, values: []string{"none", "critical", "high_or_higher", "medium_or_higher", "all"}},
+				"tool":                      {kind: kindString, required: true},
+			}},
+		},
+	}
+)
+
+func withField(fields map[string]fieldSpec, name string, spec fieldSpec) map[string]fieldSpec {
+	result := make(map[string]fieldSpec, len(fields)+1)
+	for k, v := range fields {
+		result[k] = v
+	}
+	result[name] = spec
+	return result
+}
+
+// checkRefPattern validates a ref name condition pattern, which is an fnmatch pattern or a special value
+func checkRefPattern(pattern string) error {
+	switch pattern {
+	case "~DEFAULT_BRANCH", "~ALL":
+		return nil
+	}
+	if strings.HasPrefix(pattern, "~") {
+		return fmt.Errorf("unknown special pattern '%s', expected ~DEFAULT_BRANCH or ~ALL", pattern)
+	}
+	return checkNamePattern(pattern)
+}
+
+// checkNamePattern validates an fnmatch pattern of a repository or organization name condition
+func checkNamePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern must not be empty")
+	}
+	if pattern == "~ALL" {
+		return nil
+	}
+	if _, err := fnmatchRegexp(pattern); err != nil {
+		return fmt.Errorf("malformed fnmatch pattern '%s'", pattern)
+	}
+	return nil
+}
+
+// validator collects the validation errors of a single ruleset
+type validator struct {
+	file   string
+	errors []*ValidationError
+}
+
+func (v *validator) errorf(path string, format string, args ...any) {
+	v.errors = append(v.errors, &ValidationError{File: v.file, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func isInteger(value any) bool {
+	switch n := value.(type) {
+	case int, int64, uint64:
+		return true
+	case float64:
+		return n == math.Trunc(n)
+	}
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *validator) object(path string, value any, fields map[string]fieldSpec) (map[string]any, bool) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		v.errorf(path, "must be an object, got %s", describeValue(value))
+		return nil, false
+	}
+	for _, name := range sortedKeys(fields) {
+		if _, ok := obj[name]; !ok && fields[name].required {
+			v.errorf(joinPath(path, name), "required field is missing")
+		}
+	}
+	for _, name := range sortedKeys(obj) {
+		spec, ok := fields[name]
+		if !ok {
+			v.errorf(joinPath(path, name), "unknown field")
+			continue
+		}
+		if obj[name] == nil && !spec.required {
+			continue
+		}
+		v.field(joinPath(path, name), obj[name], spec)
+	}
+	return obj, true
+}
+
+func (v *validator) field(path string, value any, spec fieldSpec) {
+	switch spec.kind {
+	case kindBool:
+		if _, ok := value.(bool); !ok {
+			v.errorf(path, "must be a boolean, got %s", describeValue(value))
+		}
+	case kindInteger:
+		if !isInteger(value) {
+			v.errorf(path, "must be an integer, got %s", describeValue(value))
+		}
+	case kindString:
+		v.stringValue(path, value, spec)
+	case kindObject:
+		v.object(path, value, spec.fields)
+	case kindRules:
+		v.rules(path, value)
+	case kindStringList, kindIntegerList, kindObjectList:
+		list, ok := value.([]any)
+		if !ok {
+			v.errorf(path, "must be an %s, got %s", spec.kind, describeValue(value))
+			return
+		}
+		for i, item := range list {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch spec.kind {
+			case kindStringList:
+				v.stringValue(itemPath, item, spec)
+			case kindIntegerList:
+				if !isInteger(item) {
+					v.errorf(itemPath, "must be an integer, got %s", describeValue(item))
+				}
+			default:
+				v.object(itemPath, item, spec.fields)
+			}
+		}
+	}
+}
+
+func (v *validator) stringValue(path string, value any, spec fieldSpec) {
+	s, ok := value.(string)
+	if !ok {
+		v.errorf(path, "must be a string, got %s", describeValue(value))
+		return
+	}
+	if len(spec.values) > 0 && !contains(spec.values, s) {
+		v.errorf(path, "invalid value '%s', expected one of: %s", s, strings.Join(spec.values, ", "))
+		return
+	}
+	if spec.check != nil {
+		if err := spec.check(s); err != nil {
+			v.errorf(path, "%s", err)
+		}
+	}
+}
+
+func (v *validator) rules(path string, value any) {
+	list, ok := value.([]any)
+	if !ok {
+		v.errorf(path, "must be an %s, got %s", kindRules, describeValue(value))
+		return
+	}
+	seen := map[string]string{}
+	for i, item := range list {
+		rulePath := fmt.Sprintf("%s[%d]", path, i)
+		rule, ok := item.(map[string]any)
+		if !ok {
+			v.errorf(rulePath, "must be an object, got %s", describeValue(item))
+			continue
+		}
+		for _, name := range sortedKeys(rule) {
+			if name != "type" && name != "parameters" {
+				v.errorf(joinPath(rulePath, name), "unknown field")
+			}
+		}
+		ruleType, ok := rule["type"].(string)
+		if !ok {
+			v.errorf(joinPath(rulePath, "type"), "required string field is missing")
+			continue
+		}
+		parameters, known := ruleParameters[ruleType]
+		if !known {
+			v.errorf(joinPath(rulePath, "type"), "unknown rule type '%s'", ruleType)
+			continue
+		}
+		if first, ok := seen[ruleType]; ok {
+			v.errorf(joinPath(rulePath, "type"), "duplicate rule type '%s', already defined at %s", ruleType, first)
+			continue
+		}
+		seen[ruleType] = rulePath
+
+		parametersPath := joinPath(rulePath, "parameters")
+		raw, hasParameters := rule["parameters"]
+		if !hasParameters {
+			if len(parameters) > 0 {
+				required := []string{}
+				for _, name := range sortedKeys(parameters) {
+					if parameters[name].required {
+						required = append(required, name)
+					}
+				}
+				if len(required) > 0 {
+					v.errorf(parametersPath, "required parameters are missing: %s", strings.Join(required, ", "))
+				}
+			}
+			continue
+		}
+		obj, ok := v.object(parametersPath, raw, parameters)
+		if !ok {
+			continue
+		}
+		if obj["operator"] == "regex" {
+			if pattern, ok := obj["pattern"].(string); ok {
+				if _, err := regexp.Compile(pattern); err != nil {
+					v.errorf(joinPath(parametersPath, "pattern"), "invalid regular expression: %s", err)
+				}
+			}
+		}
+	}
+}
+
+func describeValue(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case int, int64, uint64, float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRuleset validates a single ruleset parsed into a generic value. Paths of errors start at '$'.
+func ValidateRuleset(file string, ruleset any) []*ValidationError {
+	v := &validator{file: file}
+	obj, ok := v.object("$", ruleset, rulesetFields)
+	if ok {
+		if name, ok := obj["name"].(string); ok && strings.TrimSpace(name) == "" {
+			v.errorf("$.name", "must not be empty")
+		}
+	}
+	return v.errors
+}
+
+// ValidateConfigData validates every ruleset of ruleset file data without contacting GitHub.
+// The format is detected from the content when empty. Rulesets of a file holding several of them are reported as 'name#N'.
+func ValidateConfigData(name string, data []byte, format Format) []*ValidationError {
+	items, err := parseGenericRulesets(data, format)
+	if err != nil {
+		return []*ValidationError{{File: name, Path: "$", Message: err.Error()}}
+	}
+	if len(items) == 0 {
+		return []*ValidationError{{File: name, Path: "$", Message: "no ruleset found"}}
+	}
+	var errs []*ValidationError
+	for i, item := range items {
+		file := name
+		if len(items) > 1 {
+			file = fmt.Sprintf("%s#%d", name, i+1)
+		}
+		errs = append(errs, ValidateRuleset(file, item)...)
+	}
+	return errs
+}
+
+// ValidateInput validates the ruleset files of stdin ('-'), a directory, a glob pattern or a single file.
+// It returns the validated files and the problems found in them. When td is not nil, files containing
+// template actions are rendered with it before they are validated.
+func ValidateInput(input string, td *TemplateData) ([]string, []*ValidationError, error) {
+	paths, err := configInputPaths(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	var errs []*ValidationError
+	for _, p := range paths {
+		data, err := readConfigData(p, td)
+		if err != nil {
+			errs = append(errs, &ValidationError{File: p, Path: "$", Message: err.Error()})
+			continue
+		}
+		errs = append(errs, ValidateConfigData(p, data, FormatFromPath(p))...)
+	}
+	return paths, errs, nil
+}
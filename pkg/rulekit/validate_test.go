@@ -0,0 +1,98 @@
+package rulekit
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestValidateRuleset(t *testing.T) {
+	tests := []struct {
+		name    string
+		ruleset string
+		want    []string
+	}{
+		{
+			name:    "valid",
+			ruleset: `{"name":"main","target":"branch","enforcement":"active","conditions":{"ref_name":{"include":["~DEFAULT_BRANCH","refs/heads/release/*"],"exclude":[]}},"rules":[{"type":"deletion"},{"type":"pull_request","parameters":{"dismiss_stale_reviews_on_push":true,"require_code_owner_review":false,"require_last_push_approval":false,"required_approving_review_count":1,"required_review_thread_resolution":false}}],"bypass_actors":[{"actor_id":5,"actor_type":"RepositoryRole","bypass_mode":"always"}]}`,
+		},
+		{
+			name:    "not an object",
+			ruleset: `[]`,
+			want:    []string{"$"},
+		},
+		{
+			name:    "missing required fields",
+			ruleset: `{"target":"branch"}`,
+			want:    []string{"$.enforcement", "$.name"},
+		},
+		{
+			name:    "empty name",
+			ruleset: `{"name":" ","enforcement":"active"}`,
+			want:    []string{"$.name"},
+		},
+		{
+			name:    "unknown field and invalid values",
+			ruleset: `{"name":"main","enforcement":"enabled","target":"commit","color":"red"}`,
+			want:    []string{"$.color", "$.enforcement", "$.target"},
+		},
+		{
+			name:    "wrong types",
+			ruleset: `{"name":1,"enforcement":"active","id":1.5,"bypass_actors":{}}`,
+			want:    []string{"$.bypass_actors", "$.id", "$.name"},
+		},
+		{
+			name:    "unknown and duplicate rule types",
+			ruleset: `{"name":"main","enforcement":"active","rules":[{"type":"deletion"},{"type":"teleport"},{"type":"deletion"},{"parameters":{}}]}`,
+			want:    []string{"$.rules[1].type", "$.rules[2].type", "$.rules[3].type"},
+		},
+		{
+			name:    "missing rule parameters",
+			ruleset: `{"name":"main","enforcement":"active","rules":[{"type":"max_file_size"},{"type":"pull_request","parameters":{"required_approving_review_count":"1"}}]}`,
+			want: []string{
+				"$.rules[0].parameters",
+				"$.rules[1].parameters.dismiss_stale_reviews_on_push",
+				"$.rules[1].parameters.require_code_owner_review",
+				"$.rules[1].parameters.require_last_push_approval",
+				"$.rules[1].parameters.required_review_thread_resolution",
+				"$.rules[1].parameters.required_approving_review_count",
+			},
+		},
+		{
+			name:    "invalid regular expression",
+			ruleset: `{"name":"main","enforcement":"active","rules":[{"type":"commit_message_pattern","parameters":{"operator":"regex","pattern":"("}}]}`,
+			want:    []string{"$.rules[0].parameters.pattern"},
+		},
+		{
+			name:    "malformed ref patterns",
+			ruleset: `{"name":"main","enforcement":"active","conditions":{"ref_name":{"include":["~MAIN","refs/heads/[main"],"exclude":[""]}}}`,
+			want:    []string{"$.conditions.ref_name.exclude[0]", "$.conditions.ref_name.include[0]", "$.conditions.ref_name.include[1]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ruleset any
+			if err := json.Unmarshal([]byte(tt.ruleset), &ruleset); err != nil {
+				t.Fatalf("failed to parse ruleset: %v", err)
+			}
+			var got []string
+			for _, err := range ValidateRuleset("ruleset.json", ruleset) {
+				got = append(got, err.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateRuleset() paths = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfigData(t *testing.T) {
+	data := []byte("name: main\nenforcement: active\n---\nname: tags\nenforcement: on\n")
+	errs := ValidateConfigData("rulesets.yaml", data, FormatYAML)
+	if len(errs) != 1 {
+		t.Fatalf("ValidateConfigData() = %v, want 1 error", errs)
+	}
+	if errs[0].File != "rulesets.yaml#2" || errs[0].Path != "$.enforcement" {
+		t.Errorf("ValidateConfigData() = %s, want rulesets.yaml#2: $.enforcement", errs[0])
+	}
+}
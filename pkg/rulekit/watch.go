@@ -0,0 +1,104 @@
+package rulekit
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/srz-zumix/go-gh-extension/pkg/gh"
+	"github.com/srz-zumix/go-gh-extension/pkg/logger"
+)
+
+// RuleSuiteWatcher polls the rule suites of a repository or organization (organization when repo.Name is empty)
+// and reports the ones it has not seen before
+type RuleSuiteWatcher struct {
+	g       *gh.GitHubClient
+	repo    repository.Repository
+	options gh.ListRuleSuitesOptions
+	seen    map[int64]bool
+}
+
+// NewRuleSuiteWatcher returns a watcher of the rule suites matching options. The time period of options is chosen
+// by the watcher to cover the poll interval.
+func NewRuleSuiteWatcher(g *gh.GitHubClient, repo repository.Repository, options gh.ListRuleSuitesOptions) *RuleSuiteWatcher {
+	return &RuleSuiteWatcher{g: g, repo: repo, options: options}
+}
+
+func (w *RuleSuiteWatcher) list(ctx context.Context) ([]*gh.RuleSuite, error) {
+	if w.repo.Name == "" {
+		return gh.ListOrgRuleSuites(ctx, w.g, w.repo, &w.options)
+	}
+	return gh.ListRepositoryRuleSuites(ctx, w.g, w.repo, &w.options)
+}
+
+// poll lists the rule suites of the watched period and returns the ones not seen yet, oldest first. The first poll
+// marks every listed rule suite as seen; afterwards a rule suite is only marked as seen once it was reported.
+// Only the rule suites of the current period are remembered, as older ones cannot show up again.
+func (w *RuleSuiteWatcher) poll(ctx context.Context) ([]*gh.RuleSuite, error) {
+	suites, err := w.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int64]bool, len(suites))
+	var fresh []*gh.RuleSuite
+	for _, suite := range suites {
+		id := valueOf(suite.ID)
+		if w.seen == nil || w.seen[id] {
+			seen[id] = true
+			continue
+		}
+		fresh = append(fresh, suite)
+	}
+	w.seen = seen
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return valueOf(fresh[i].ID) < valueOf(fresh[j].ID)
+	})
+	return fresh, nil
+}
+
+// Watch polls every interval until ctx is done and calls handle with each new rule suite including its rule evaluations.
+// Rule suites that exist when watching starts are not reported. Failed polls and rule suites whose details could not
+// be fetched are logged and retried at the next interval.
+func (w *RuleSuiteWatcher) Watch(ctx context.Context, interval time.Duration, handle func(*gh.RuleSuite) error) error {
+	w.options.TimePeriod = "hour"
+	if interval >= time.Hour {
+		w.options.TimePeriod = "day"
+	}
+	if _, err := w.poll(ctx); err != nil {
+		return err
+	}
+	logger.Info("Watching rule suites", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		suites, err := w.poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			logger.Warn("Failed to list rule suites, retrying at the next interval", "error", err)
+			continue
+		}
+		for _, suite := range suites {
+			detail, err := getRuleSuiteDetail(ctx, w.g, w.repo, suite)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				logger.Warn("Failed to get rule suite, retrying at the next interval", "id", valueOf(suite.ID), "error", err)
+				continue
+			}
+			w.seen[valueOf(suite.ID)] = true
+			if err := handle(detail); err != nil {
+				return err
+			}
+		}
+	}
+}